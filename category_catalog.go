@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// 비고 템플릿 기본값 (MM/DD_이름_카테고리)
+const DEFAULT_REMARK_TEMPLATE = "{date}_{names}_{label}"
+
+// 카테고리별 필수 입력 필드 (업로드 메타데이터 키 → 표시 이름)
+var categoryFieldLabels = map[string]string{
+	"business_content": "출장내용",
+	"purpose":          "용도",
+	"additional_names": "추가 이름",
+	"remarks":          "비고",
+}
+
+// 비고 템플릿에서 사용할 수 있는 치환자
+var remarkPlaceholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+var remarkPlaceholders = map[string]bool{
+	"date": true, "names": true, "label": true, "business_content": true, "purpose": true,
+}
+
+// CategoryDefinition 카테고리 정의
+type CategoryDefinition struct {
+	Code           string          `json:"code"`
+	Label          string          `json:"label"`
+	Active         bool            `json:"active"`
+	Default        bool            `json:"default,omitempty"`
+	RequiredFields []string        `json:"requiredFields,omitempty"` // 업로드 시 필수 메타데이터
+	RemarkTemplate string          `json:"remarkTemplate,omitempty"` // 비어 있으면 DEFAULT_REMARK_TEMPLATE
+	Keywords       []string        `json:"keywords,omitempty"`       // 파일명 자동 감지 키워드 (프론트엔드용)
+	Policy         *CategoryPolicy `json:"policy,omitempty"`         // 경비 정책 (없으면 검사 안 함)
+}
+
+// CategoryCatalogFile 카테고리 설정 파일 형식
+type CategoryCatalogFile struct {
+	Categories []CategoryDefinition `json:"categories"`
+}
+
+// CategoryCatalog 카테고리 레지스트리 (OCR, Excel, API 공용)
+type CategoryCatalog struct {
+	categories  []CategoryDefinition
+	byCode      map[string]*CategoryDefinition
+	defaultCode string
+	source      string
+}
+
+// RemarkValues 비고 템플릿 치환 값
+type RemarkValues struct {
+	Date            string // MM/DD
+	Names           string // 사용자 이름 (추가 이름 포함)
+	BusinessContent string
+	Purpose         string
+}
+
+var (
+	categoryCatalog     *CategoryCatalog
+	categoryCatalogOnce sync.Once
+	categoryCatalogErr  error
+)
+
+// 기본 카테고리 목록 (경비 정책 없음, 한도 등은 CATEGORY_CATALOG_FILE의 policy로 설정)
+func defaultCategoryDefinitions() []CategoryDefinition {
+	return []CategoryDefinition{
+		{Code: "6110", Label: "조식", Active: true, Keywords: []string{"조식", "아침"}},
+		{Code: "6120", Label: "중식", Active: true, Keywords: []string{"중식", "점심"}},
+		{Code: "6130", Label: "석식", Active: true, Default: true, Keywords: []string{"석식", "저녁"}},
+		{Code: "6310", Label: "교통정산", Active: true, Keywords: []string{"교통", "택시", "지하철"}},
+		{
+			Code: "6320", Label: "국내출장", Active: true,
+			RequiredFields: []string{"business_content", "purpose"},
+			RemarkTemplate: "{business_content}_{names}_{purpose}",
+			Keywords:       []string{"국내출장", "출장"},
+		},
+	}
+}
+
+// 전역 카테고리 레지스트리 반환 (CATEGORY_CATALOG_FILE이 없으면 기본 목록 사용)
+func getCategoryCatalog() (*CategoryCatalog, error) {
+	categoryCatalogOnce.Do(func() {
+		categoryCatalog, categoryCatalogErr = loadCategoryCatalog(getCategoryCatalogFile())
+	})
+	return categoryCatalog, categoryCatalogErr
+}
+
+// 카테고리 레지스트리 로드 (경로가 비어 있으면 기본 목록)
+func loadCategoryCatalog(path string) (*CategoryCatalog, error) {
+	definitions := defaultCategoryDefinitions()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("카테고리 설정 파일 읽기 실패: %v", err)
+		}
+		var file CategoryCatalogFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("카테고리 설정 파일 파싱 실패 (%s): %v", path, err)
+		}
+		definitions = file.Categories
+	}
+
+	catalog, err := NewCategoryCatalog(definitions)
+	if err != nil {
+		return nil, fmt.Errorf("카테고리 설정 검증 실패: %v", err)
+	}
+	catalog.source = path
+	if path != "" {
+		log.Printf("카테고리 설정 로드: %d개 (%s)", len(catalog.categories), path)
+	}
+	return catalog, nil
+}
+
+// 카테고리 레지스트리 생성자 (코드 중복, 기본값, 템플릿 검증)
+func NewCategoryCatalog(definitions []CategoryDefinition) (*CategoryCatalog, error) {
+	catalog := &CategoryCatalog{
+		categories: make([]CategoryDefinition, len(definitions)),
+		byCode:     make(map[string]*CategoryDefinition),
+	}
+	copy(catalog.categories, definitions)
+
+	for i := range catalog.categories {
+		category := &catalog.categories[i]
+		if category.Code == "" || category.Label == "" {
+			return nil, fmt.Errorf("카테고리 %d: code와 label이 필요합니다", i+1)
+		}
+		if _, exists := catalog.byCode[category.Code]; exists {
+			return nil, fmt.Errorf("카테고리 %s: 코드가 중복되었습니다", category.Code)
+		}
+		for _, field := range category.RequiredFields {
+			if _, known := categoryFieldLabels[field]; !known {
+				return nil, fmt.Errorf("카테고리 %s: 알 수 없는 필수 필드 %q", category.Code, field)
+			}
+		}
+		if category.RemarkTemplate == "" {
+			category.RemarkTemplate = DEFAULT_REMARK_TEMPLATE
+		}
+		for _, match := range remarkPlaceholderPattern.FindAllStringSubmatch(category.RemarkTemplate, -1) {
+			if !remarkPlaceholders[match[1]] {
+				return nil, fmt.Errorf("카테고리 %s: 알 수 없는 비고 치환자 {%s}", category.Code, match[1])
+			}
+		}
+		if category.Policy != nil {
+			for code, severity := range category.Policy.Severities {
+				if _, known := defaultPolicySeverities[code]; !known {
+					return nil, fmt.Errorf("카테고리 %s: 알 수 없는 정책 코드 %q", category.Code, code)
+				}
+				if severity != POLICY_SEVERITY_WARNING && severity != POLICY_SEVERITY_ERROR {
+					return nil, fmt.Errorf("카테고리 %s: 잘못된 심각도 %q (warning | error)", category.Code, severity)
+				}
+			}
+		}
+		if category.Default {
+			if catalog.defaultCode != "" {
+				return nil, fmt.Errorf("기본 카테고리가 여러 개입니다 (%s, %s)", catalog.defaultCode, category.Code)
+			}
+			if !category.Active {
+				return nil, fmt.Errorf("카테고리 %s: 기본 카테고리는 활성 상태여야 합니다", category.Code)
+			}
+			catalog.defaultCode = category.Code
+		}
+		catalog.byCode[category.Code] = category
+	}
+
+	if catalog.defaultCode == "" {
+		return nil, fmt.Errorf("기본 카테고리(default)가 지정되지 않았습니다")
+	}
+	return catalog, nil
+}
+
+// 코드로 카테고리 조회 (없으면 nil)
+func (c *CategoryCatalog) Get(code string) *CategoryDefinition {
+	return c.byCode[code]
+}
+
+// 전체 카테고리 목록 (비활성 포함, 설정 순서)
+func (c *CategoryCatalog) All() []CategoryDefinition {
+	return append([]CategoryDefinition(nil), c.categories...)
+}
+
+// 기본 카테고리 코드
+func (c *CategoryCatalog) DefaultCode() string {
+	return c.defaultCode
+}
+
+// 카테고리 코드를 라벨로 변환 (등록되지 않은 코드는 그대로 반환)
+func (c *CategoryCatalog) Label(code string) string {
+	if category := c.byCode[code]; category != nil {
+		return category.Label
+	}
+	return code
+}
+
+// 활성 카테고리 코드 → 라벨
+func (c *CategoryCatalog) ActiveLabels() map[string]string {
+	labels := make(map[string]string)
+	for _, category := range c.categories {
+		if category.Active {
+			labels[category.Code] = category.Label
+		}
+	}
+	return labels
+}
+
+// 업로드 메타데이터 검증 (비활성/미등록 코드, 필수 필드 누락)
+func (c *CategoryCatalog) ValidateMetadata(code string, meta map[string]string) error {
+	category := c.byCode[code]
+	if category == nil {
+		return fmt.Errorf("알 수 없는 카테고리입니다: %s", code)
+	}
+	if !category.Active {
+		return fmt.Errorf("사용이 중지된 카테고리입니다: %s (%s)", category.Label, code)
+	}
+	for _, field := range category.RequiredFields {
+		if strings.TrimSpace(meta[field]) == "" {
+			return fmt.Errorf("%s 카테고리는 %s 입력이 필요합니다", category.Label, categoryFieldLabels[field])
+		}
+	}
+	return nil
+}
+
+// 카테고리 비고 템플릿 적용
+func (c *CategoryCatalog) RenderRemark(code string, values RemarkValues) string {
+	template := DEFAULT_REMARK_TEMPLATE
+	if category := c.byCode[code]; category != nil {
+		template = category.RemarkTemplate
+	}
+
+	replacements := map[string]string{
+		"date":             values.Date,
+		"names":            values.Names,
+		"label":            c.Label(code),
+		"business_content": values.BusinessContent,
+		"purpose":          values.Purpose,
+	}
+	return remarkPlaceholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		return replacements[placeholder[1:len(placeholder)-1]]
+	})
+}
+
+// 현재 카테고리 레지스트리 (시작 시 검증되므로 로드 실패 시에는 기본 목록 사용)
+func currentCategoryCatalog() *CategoryCatalog {
+	catalog, err := getCategoryCatalog()
+	if err != nil {
+		catalog, _ = NewCategoryCatalog(defaultCategoryDefinitions())
+	}
+	return catalog
+}
+
+// 카테고리 코드를 라벨로 변환
+func getCategoryLabel(code string) string {
+	return currentCategoryCatalog().Label(code)
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CategoryRule 카테고리 자동 조정 규칙 (조건은 모두 만족해야 적용)
+type CategoryRule struct {
+	ID          string `json:"id"`
+	Priority    int    `json:"priority"`    // 높을수록 먼저 평가
+	Explanation string `json:"explanation"` // 규칙 적용 사유 (사용자 표시용)
+
+	// 조건 (비어 있으면 검사하지 않음)
+	AppliesTo        []string             `json:"appliesTo,omitempty"`        // 원래 카테고리 코드
+	TimeRanges       []CategoryTimeRange  `json:"timeRanges,omitempty"`       // 사용 시각 범위
+	Weekdays         []string             `json:"weekdays,omitempty"`         // mon, tue, wed, thu, fri, sat, sun
+	MerchantKeywords []string             `json:"merchantKeywords,omitempty"` // 사용처 포함 문자열 (대소문자 무시)
+	Amount           *CategoryAmountRange `json:"amount,omitempty"`           // 사용액 범위
+
+	// 결과 (keep이면 원래 카테고리 유지)
+	Category string `json:"category,omitempty"`
+	Keep     bool   `json:"keep,omitempty"`
+
+	timeRanges [][2]int // 분 단위 [시작, 종료)
+	weekdays   map[time.Weekday]bool
+}
+
+// CategoryTimeRange 시각 범위 ("HH:MM", 종료 시각 미포함, 자정을 넘길 수 있음)
+type CategoryTimeRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// CategoryAmountRange 사용액 범위 (원, 경계 포함)
+type CategoryAmountRange struct {
+	Min *int64 `json:"min,omitempty"`
+	Max *int64 `json:"max,omitempty"`
+}
+
+// CategoryRuleFile 규칙 파일 형식
+type CategoryRuleFile struct {
+	Rules []CategoryRule `json:"rules"`
+}
+
+// CategoryRuleInput 규칙 평가 입력
+type CategoryRuleInput struct {
+	Category      string
+	IssueDateTime string          // OCR 사용일 원문
+	DateContext   DateTimeContext // 사용일 추론 기준 (업로드 일시, 청구 기간)
+	Merchant      string
+	Amount        Money
+}
+
+// CategoryRuleMatch 적용된 규칙 정보
+type CategoryRuleMatch struct {
+	RuleID      string `json:"ruleId"`
+	Explanation string `json:"explanation"`
+	Category    string `json:"category"`
+}
+
+// CategoryRuleEngine 우선순위 순으로 첫 번째로 일치하는 규칙을 적용
+type CategoryRuleEngine struct {
+	mu      sync.RWMutex
+	rules   []CategoryRule
+	source  string    // 규칙 파일 경로 (기본 규칙이면 빈 값)
+	modTime time.Time // 마지막으로 로드한 파일 수정 시각
+}
+
+var (
+	categoryRuleEngine     *CategoryRuleEngine
+	categoryRuleEngineOnce sync.Once
+	categoryRuleEngineErr  error
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// 기존 하드코딩 시간대와 동일한 기본 규칙
+func defaultCategoryRules() []CategoryRule {
+	return []CategoryRule{
+		{ID: "business-trip-keep", Priority: 100, Explanation: "국내출장은 시간과 관계없이 유지", AppliesTo: []string{"6320"}, Keep: true},
+		{ID: "ambiguous-15h", Priority: 50, Explanation: "15시대는 애매한 시간대로 원래 카테고리 유지", TimeRanges: []CategoryTimeRange{{Start: "15:00", End: "16:00"}}, Keep: true},
+		{ID: "breakfast", Priority: 10, Explanation: "04:00-09:59 사용은 조식", TimeRanges: []CategoryTimeRange{{Start: "04:00", End: "10:00"}}, Category: "6110"},
+		{ID: "lunch", Priority: 10, Explanation: "10:00-14:59 사용은 중식", TimeRanges: []CategoryTimeRange{{Start: "10:00", End: "15:00"}}, Category: "6120"},
+		{ID: "dinner", Priority: 10, Explanation: "16:00-03:59 사용은 석식 (다음날 새벽 포함)", TimeRanges: []CategoryTimeRange{{Start: "16:00", End: "04:00"}}, Category: "6130"},
+	}
+}
+
+// 전역 규칙 엔진 반환 (CATEGORY_RULES_FILE이 없으면 기본 규칙 사용)
+func getCategoryRuleEngine() (*CategoryRuleEngine, error) {
+	categoryRuleEngineOnce.Do(func() {
+		categoryRuleEngine, categoryRuleEngineErr = NewCategoryRuleEngine(getCategoryRulesFile())
+	})
+	return categoryRuleEngine, categoryRuleEngineErr
+}
+
+// 규칙 엔진 생성자 (파일 경로가 비어 있으면 기본 규칙)
+func NewCategoryRuleEngine(path string) (*CategoryRuleEngine, error) {
+	engine := &CategoryRuleEngine{source: path}
+	if path == "" {
+		rules, err := prepareCategoryRules(defaultCategoryRules())
+		if err != nil {
+			return nil, err
+		}
+		engine.rules = rules
+		return engine, nil
+	}
+
+	if err := engine.reload(); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+// 규칙 파일 다시 읽기
+func (e *CategoryRuleEngine) reload() error {
+	info, err := os.Stat(e.source)
+	if err != nil {
+		return fmt.Errorf("카테고리 규칙 파일 확인 실패: %v", err)
+	}
+	data, err := os.ReadFile(e.source)
+	if err != nil {
+		return fmt.Errorf("카테고리 규칙 파일 읽기 실패: %v", err)
+	}
+
+	var file CategoryRuleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("카테고리 규칙 파일 파싱 실패 (%s): %v", e.source, err)
+	}
+	rules, err := prepareCategoryRules(file.Rules)
+	if err != nil {
+		return fmt.Errorf("카테고리 규칙 파일 검증 실패 (%s): %v", e.source, err)
+	}
+
+	e.mu.Lock()
+	e.rules = rules
+	e.modTime = info.ModTime()
+	e.mu.Unlock()
+
+	log.Printf("카테고리 규칙 로드: %d개 (%s)", len(rules), e.source)
+	return nil
+}
+
+// 파일이 변경되었으면 다시 로드 (실패 시 기존 규칙 유지)
+func (e *CategoryRuleEngine) reloadIfChanged() {
+	if e.source == "" {
+		return
+	}
+	info, err := os.Stat(e.source)
+	if err != nil {
+		return
+	}
+
+	e.mu.RLock()
+	changed := !info.ModTime().Equal(e.modTime)
+	e.mu.RUnlock()
+
+	if changed {
+		if err := e.reload(); err != nil {
+			log.Printf("⚠️ %v (기존 규칙 유지)", err)
+		}
+	}
+}
+
+// 현재 적용 중인 규칙 목록
+func (e *CategoryRuleEngine) Rules() []CategoryRule {
+	e.reloadIfChanged()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]CategoryRule(nil), e.rules...)
+}
+
+// 규칙 평가 (일치하는 규칙이 없으면 nil)
+func (e *CategoryRuleEngine) Evaluate(input CategoryRuleInput) *CategoryRuleMatch {
+	e.reloadIfChanged()
+
+	issuedAt, hasDate, hasTime := parseCategoryRuleDateTime(input.IssueDateTime, input.DateContext)
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, rule := range e.rules {
+		if !rule.matches(input, issuedAt, hasDate, hasTime) {
+			continue
+		}
+
+		category := rule.Category
+		if rule.Keep {
+			category = input.Category
+		}
+		return &CategoryRuleMatch{RuleID: rule.ID, Explanation: rule.Explanation, Category: category}
+	}
+	return nil
+}
+
+func (rule *CategoryRule) matches(input CategoryRuleInput, issuedAt time.Time, hasDate, hasTime bool) bool {
+	if len(rule.AppliesTo) > 0 && !containsString(rule.AppliesTo, input.Category) {
+		return false
+	}
+
+	if len(rule.timeRanges) > 0 {
+		if !hasTime {
+			return false
+		}
+		minute := issuedAt.Hour()*60 + issuedAt.Minute()
+		inRange := false
+		for _, r := range rule.timeRanges {
+			if (r[0] <= r[1] && minute >= r[0] && minute < r[1]) ||
+				(r[0] > r[1] && (minute >= r[0] || minute < r[1])) {
+				inRange = true
+				break
+			}
+		}
+		if !inRange {
+			return false
+		}
+	}
+
+	if len(rule.weekdays) > 0 && (!hasDate || !rule.weekdays[issuedAt.Weekday()]) {
+		return false
+	}
+
+	if len(rule.MerchantKeywords) > 0 {
+		merchant := strings.ToLower(input.Merchant)
+		found := false
+		for _, keyword := range rule.MerchantKeywords {
+			if strings.Contains(merchant, strings.ToLower(keyword)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if rule.Amount != nil {
+		if rule.Amount.Min != nil && input.Amount < MoneyFromWon(*rule.Amount.Min) {
+			return false
+		}
+		if rule.Amount.Max != nil && input.Amount > MoneyFromWon(*rule.Amount.Max) {
+			return false
+		}
+	}
+	return true
+}
+
+// 규칙 검증 및 우선순위 정렬
+func prepareCategoryRules(rules []CategoryRule) ([]CategoryRule, error) {
+	catalog := currentCategoryCatalog()
+	seen := make(map[string]bool)
+	prepared := make([]CategoryRule, 0, len(rules))
+
+	for i, rule := range rules {
+		if rule.ID == "" {
+			return nil, fmt.Errorf("규칙 %d: id가 없습니다", i+1)
+		}
+		if seen[rule.ID] {
+			return nil, fmt.Errorf("규칙 %s: id가 중복되었습니다", rule.ID)
+		}
+		seen[rule.ID] = true
+
+		if rule.Keep == (rule.Category != "") {
+			return nil, fmt.Errorf("규칙 %s: category와 keep 중 하나만 지정해야 합니다", rule.ID)
+		}
+		for _, code := range append([]string{rule.Category}, rule.AppliesTo...) {
+			if code != "" && catalog.Get(code) == nil {
+				return nil, fmt.Errorf("규칙 %s: 등록되지 않은 카테고리 %s", rule.ID, code)
+			}
+		}
+
+		rule.timeRanges = nil
+		for _, timeRange := range rule.TimeRanges {
+			start, err := parseClockMinutes(timeRange.Start)
+			if err != nil {
+				return nil, fmt.Errorf("규칙 %s: %v", rule.ID, err)
+			}
+			end, err := parseClockMinutes(timeRange.End)
+			if err != nil {
+				return nil, fmt.Errorf("규칙 %s: %v", rule.ID, err)
+			}
+			rule.timeRanges = append(rule.timeRanges, [2]int{start, end})
+		}
+
+		rule.weekdays = nil
+		if len(rule.Weekdays) > 0 {
+			rule.weekdays = make(map[time.Weekday]bool)
+			for _, name := range rule.Weekdays {
+				weekday, exists := weekdayNames[strings.ToLower(name)]
+				if !exists {
+					return nil, fmt.Errorf("규칙 %s: 알 수 없는 요일 %q", rule.ID, name)
+				}
+				rule.weekdays[weekday] = true
+			}
+		}
+
+		if rule.Amount != nil && rule.Amount.Min != nil && rule.Amount.Max != nil && *rule.Amount.Min > *rule.Amount.Max {
+			return nil, fmt.Errorf("규칙 %s: 금액 범위가 잘못되었습니다", rule.ID)
+		}
+
+		prepared = append(prepared, rule)
+	}
+
+	// 우선순위 내림차순 (같으면 파일 순서 유지)
+	sort.SliceStable(prepared, func(i, j int) bool {
+		return prepared[i].Priority > prepared[j].Priority
+	})
+	return prepared, nil
+}
+
+// "HH:MM" → 자정 기준 분 ("24:00" 허용)
+func parseClockMinutes(clock string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(clock, "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("잘못된 시각 형식 %q (HH:MM)", clock)
+	}
+	if hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("잘못된 시각 %q", clock)
+	}
+	return hour*60 + minute, nil
+}
+
+// 사용일 원문에서 날짜/시각 추출 (원문에 있는 구성 요소만 인정)
+func parseCategoryRuleDateTime(issueDateTime string, dateContext DateTimeContext) (time.Time, bool, bool) {
+	parsed, err := ParseDateTimeWithContext(issueDateTime, dateContext)
+	if err != nil {
+		return time.Time{}, false, false
+	}
+	return parsed.Time, parsed.HasDate(), parsed.HasTime()
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
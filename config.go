@@ -1,13 +1,10 @@
 package main
 
 import (
-	"fmt"
+	"errors"
 	"os"
-	"regexp"
-	"sort"
 	"strconv"
 	"strings"
-	"time"
 )
 
 // 환경변수 기본값 상수
@@ -18,46 +15,58 @@ const (
 	DEFAULT_MAX_FILES       = 5
 	DEFAULT_OCR_TIMEOUT     = 60
 	DEFAULT_ATTR_CD         = "8A" // 신용카드매출전표(개인)
+	DEFAULT_OCR_PROVIDER    = OCR_PROVIDER_CLOVA
+	DEFAULT_LOCAL_OCR_URL   = "http://127.0.0.1:8866/ocr"
+	DEFAULT_MOCK_FIXTURE    = "./fixtures/mock_ocr"
+	MOCK_OCR_SECRET         = "mock-secret"
+	DEFAULT_OCR_RECORD_DIR  = "./ocr_recordings"
+	DEFAULT_OCR_MAX_RETRIES = 2
+	DEFAULT_OCR_RETRY_BASE  = 500  // ms
+	DEFAULT_OCR_RETRY_MAX   = 8000 // ms
+	DEFAULT_OCR_CONCURRENCY = 4
+	DEFAULT_OCR_QUEUE_SIZE  = 100
+	DEFAULT_OCR_RATE_QPS    = 5.0
+	DEFAULT_OCR_RATE_BURST  = 5
+	DEFAULT_OCR_JOB_TTL     = 30 // 분
+
+	// OCR 결과 캐시 설정
+	DEFAULT_OCR_CACHE_TTL         = 1440 // 분 (24시간)
+	DEFAULT_OCR_CACHE_MAX_ENTRIES = 1000
+
+	// OCR 전 이미지 전처리 설정
+	DEFAULT_PREPROCESS_MAX_DIMENSION = 2000 // 긴 변 기준 픽셀
+	DEFAULT_PREPROCESS_JPEG_QUALITY  = 85
+
+	// HEIC 변환 명령 (<명령> <입력.heic> <출력.jpg>, heif-convert 또는 ImageMagick convert/magick)
+	DEFAULT_HEIC_CONVERT_COMMAND = "heif-convert"
+
+	// 중복 영수증 확인 설정
+	DEFAULT_DUPLICATE_EXPORT_POLICY = DUPLICATE_POLICY_WARN
+	DEFAULT_DUPLICATE_HISTORY_FILE  = "./data/receipt_history.json"
+	DEFAULT_DUPLICATE_HISTORY_DAYS  = 365
+
+	// OCR 신뢰도 검토 설정
+	DEFAULT_REVIEW_CONFIDENCE_THRESHOLD = 0.9
+	DEFAULT_REVIEW_QUEUE_FILE           = "./data/review_queue.json"
+	DEFAULT_REVIEW_EXPORT_POLICY        = REVIEW_POLICY_WARN
+	DEFAULT_REVIEW_RETENTION_DAYS       = 365
+
+	// 경비 정책 설정
+	DEFAULT_POLICY_MAX_RECEIPT_AGE_DAYS = 0 // 검사 안 함
+	DEFAULT_POLICY_ALCOHOL_KEYWORDS     = "주점,호프,포차,술집,이자카야,와인,맥주,소주,bar,pub"
+
+	// 사용일 추론 설정 (업로드일 이후 허용 일수, 시차/자정 전후 업로드 대비)
+	DEFAULT_DATE_FUTURE_TOLERANCE_DAYS = 1
+
+	// 외화 환산 설정
+	DEFAULT_FX_RATE_MAX_STALE_DAYS = 7
+
+	// 면세 사용처 키워드 (부가세 0 처리)
+	DEFAULT_TAX_EXEMPT_KEYWORDS = "면세,병원,의원,치과,한의원,약국"
 )
 
-// 지원하는 이미지 형식
-var SUPPORTED_IMAGE_FORMATS = []string{".jpg", ".jpeg", ".png", ".pdf", ".tif", ".tiff"}
-
-// 패턴 캐시 (성능 최적화)
-var (
-	dateTimePatterns     []DateTimePattern
-	simpleTimePatterns   []SimpleTimePattern
-	existingDatePatterns []*regexp.Regexp
-	patternsInitialized  bool
-)
-
-// initializePatterns 패턴들을 초기화하고 우선순위에 따라 정렬
-func initializePatterns() {
-	if patternsInitialized {
-		return
-	}
-
-	dateTimePatterns = GetDateTimePatterns()
-	simpleTimePatterns = GetSimpleTimePatterns()
-	existingDatePatterns = GetExistingDatePatterns()
-
-	// 우선순위에 따라 정렬 (성능 최적화)
-	sort.Slice(dateTimePatterns, func(i, j int) bool {
-		priorityI, existsI := PatternPriority[dateTimePatterns[i].Description]
-		priorityJ, existsJ := PatternPriority[dateTimePatterns[j].Description]
-
-		if !existsI {
-			priorityI = 999 // 우선순위가 없으면 맨 뒤로
-		}
-		if !existsJ {
-			priorityJ = 999
-		}
-
-		return priorityI < priorityJ
-	})
-
-	patternsInitialized = true
-}
+// 지원하는 이미지 형식 (업로드 화면 안내용, 실제 검증은 파일 내용 기준)
+var SUPPORTED_IMAGE_FORMATS = []string{".jpg", ".jpeg", ".png", ".pdf", ".tif", ".tiff", ".heic", ".heif", ".webp"}
 
 // 환경변수 getter 함수들 (통합)
 func getEnvString(key, defaultValue string) string {
@@ -76,6 +85,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if valueStr := os.Getenv(key); valueStr != "" {
+		if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+			return value
+		}
+	}
+	return defaultValue
+}
+
 func getEnvBool(key string) bool {
 	value := strings.ToLower(os.Getenv(key))
 	return value == "true" || value == "1" || value == "on"
@@ -90,16 +108,23 @@ func isTimeCategoryEnabled() bool {
 	return getEnvBool("TIME_CATEGORY_ENABLED")
 }
 
-// OCR 관련 설정
-func getOCRSecret() string {
+// OCR 관련 설정 (시크릿이 없으면 오류, Mock OCR 사용 시 내장 시크릿)
+func getOCRSecret() (string, error) {
 	secret := os.Getenv("X_OCR_SECRET")
+	if secret == "" && isMockOCREnabled() {
+		return MOCK_OCR_SECRET, nil
+	}
 	if secret == "" {
-		panic("X_OCR_SECRET 환경변수가 설정되지 않았습니다")
+		return "", errors.New("X_OCR_SECRET 환경변수가 설정되지 않았습니다")
 	}
-	return secret
+	return secret, nil
 }
 
 func getOCRAPIURL() string {
+	// Mock OCR 사용 시 별도 URL이 없으면 내장 엔드포인트로 연결
+	if os.Getenv("OCR_API_URL") == "" && isMockOCREnabled() {
+		return "http://127.0.0.1" + getServerPort() + MOCK_OCR_PATH
+	}
 	return getEnvString("OCR_API_URL", DEFAULT_OCR_API_URL)
 }
 
@@ -107,291 +132,281 @@ func getOCRTimeoutSeconds() int {
 	return getEnvInt("OCR_TIMEOUT_SECONDS", DEFAULT_OCR_TIMEOUT)
 }
 
-func isOCRVerboseLog() bool {
-	return getEnvBool("OCR_VERBOSE_LOG")
+// OCR 재시도 설정 (일시적 오류에만 적용)
+func getOCRMaxRetries() int {
+	return getEnvInt("OCR_MAX_RETRIES", DEFAULT_OCR_MAX_RETRIES)
 }
 
-// 서버 설정
-func getServerPort() string {
-	port := getEnvString("SERVER_PORT", DEFAULT_SERVER_PORT)
-	return ":" + port
+func getOCRRetryBaseDelayMs() int {
+	return getEnvInt("OCR_RETRY_BASE_DELAY_MS", DEFAULT_OCR_RETRY_BASE)
 }
 
-func getUploadLimitMB() int {
-	return getEnvInt("UPLOAD_LIMIT_MB", DEFAULT_UPLOAD_LIMIT_MB)
+func getOCRRetryMaxDelayMs() int {
+	return getEnvInt("OCR_RETRY_MAX_DELAY_MS", DEFAULT_OCR_RETRY_MAX)
 }
 
-func getMaxFiles() int {
-	return getEnvInt("MAX_FILES", DEFAULT_MAX_FILES)
+// OCR 작업 풀 설정 (프로세스 전역, 0 QPS는 속도 제한 없음)
+func getOCRMaxConcurrency() int {
+	return getEnvInt("OCR_MAX_CONCURRENCY", DEFAULT_OCR_CONCURRENCY)
 }
 
-// 기본값 설정들
-func getDefaultDepositorDC() string {
-	return getEnvString("DEFAULT_DEPOSITOR_DC", "")
+func getOCRQueueSize() int {
+	return getEnvInt("OCR_QUEUE_SIZE", DEFAULT_OCR_QUEUE_SIZE)
 }
 
-func getDefaultDeptCD() string {
-	return getEnvString("DEFAULT_DEPT_CD", "")
+func getOCRRateLimitQPS() float64 {
+	return getEnvFloat("OCR_RATE_LIMIT_QPS", DEFAULT_OCR_RATE_QPS)
 }
 
-func getDefaultEmpCD() string {
-	return getEnvString("DEFAULT_EMP_CD", "")
+func getOCRRateLimitBurst() int {
+	return getEnvInt("OCR_RATE_LIMIT_BURST", DEFAULT_OCR_RATE_BURST)
 }
 
-func getDefaultBankCD() string {
-	return getEnvString("DEFAULT_BANK_CD", "")
+// 비동기 OCR 작업 보관 시간 (종료 후 기준)
+func getOCRJobTTLMinutes() int {
+	return getEnvInt("OCR_JOB_TTL_MINUTES", DEFAULT_OCR_JOB_TTL)
 }
 
-func getDefaultBANB() string {
-	return getEnvString("DEFAULT_BA_NB", "")
+// OCR 결과 캐시 사용 여부 (기본값 활성화)
+func isOCRCacheEnabled() bool {
+	if os.Getenv("OCR_CACHE_ENABLED") == "" {
+		return true
+	}
+	return getEnvBool("OCR_CACHE_ENABLED")
 }
 
-func getDefaultAttrCD() string {
-	return getEnvString("DEFAULT_ATTR_CD", DEFAULT_ATTR_CD)
+func getOCRCacheTTLMinutes() int {
+	return getEnvInt("OCR_CACHE_TTL_MINUTES", DEFAULT_OCR_CACHE_TTL)
 }
 
-// 파일 형식 검증
-func isSupportedImageFormat(filename string) bool {
-	filename = strings.ToLower(filename)
-	for _, format := range SUPPORTED_IMAGE_FORMATS {
-		if strings.HasSuffix(filename, format) {
-			return true
-		}
+func getOCRCacheMaxEntries() int {
+	return getEnvInt("OCR_CACHE_MAX_ENTRIES", DEFAULT_OCR_CACHE_MAX_ENTRIES)
+}
+
+// OCR 전 이미지 전처리 사용 여부 (기본값 활성화)
+func isPreprocessEnabled() bool {
+	if os.Getenv("PREPROCESS_ENABLED") == "" {
+		return true
 	}
-	return false
+	return getEnvBool("PREPROCESS_ENABLED")
 }
 
-// 파일 확장자에서 형식 추출
-func getImageFormat(filename string) string {
-	filename = strings.ToLower(filename)
+// 전처리 축소 기준 (긴 변 최대 픽셀, 0이면 축소 안 함)
+func getPreprocessMaxDimension() int {
+	return max(0, getEnvInt("PREPROCESS_MAX_DIMENSION", DEFAULT_PREPROCESS_MAX_DIMENSION))
+}
 
-	if strings.HasSuffix(filename, ".jpeg") {
-		return "jpeg"
-	}
-	if strings.HasSuffix(filename, ".jpg") {
-		return "jpg"
-	}
-	if strings.HasSuffix(filename, ".png") {
-		return "png"
-	}
-	if strings.HasSuffix(filename, ".pdf") {
-		return "pdf"
-	}
-	if strings.HasSuffix(filename, ".tiff") {
-		return "tiff"
-	}
-	if strings.HasSuffix(filename, ".tif") {
-		return "tif"
+// 전처리 흑백 변환 여부 (기본값 활성화)
+func isPreprocessGrayscale() bool {
+	if os.Getenv("PREPROCESS_GRAYSCALE") == "" {
+		return true
 	}
+	return getEnvBool("PREPROCESS_GRAYSCALE")
+}
 
-	return "jpg" // 기본값
+// 전처리 JPEG 품질 (1~100)
+func getPreprocessJPEGQuality() int {
+	return min(100, max(1, getEnvInt("PREPROCESS_JPEG_QUALITY", DEFAULT_PREPROCESS_JPEG_QUALITY)))
 }
 
-// 통합된 날짜 변환 함수
-func convertDateToYYYYMMDD(dateText string) string {
-	if dateText == "" {
-		return dateText
+// 전처리 영수증 영역 자동 자르기 여부 (기본값 비활성화)
+func isPreprocessAutoCrop() bool {
+	return getEnvBool("PREPROCESS_AUTO_CROP")
+}
+
+// HEIC 변환 명령 ("off"이면 HEIC 업로드 거부)
+func getHEICConvertCommand() string {
+	command := getEnvString("HEIC_CONVERT_COMMAND", DEFAULT_HEIC_CONVERT_COMMAND)
+	if strings.EqualFold(command, "off") {
+		return ""
 	}
+	return command
+}
 
-	// 패턴 초기화
-	initializePatterns()
+// 업로드 원본 보관 폴더 (비어 있으면 보관 안 함)
+func getUploadArchiveDir() string {
+	return os.Getenv("UPLOAD_ARCHIVE_DIR")
+}
 
-	// 기존 YYYYMMDD 형식 확인
-	for _, pattern := range existingDatePatterns {
-		if matches := pattern.FindStringSubmatch(dateText); len(matches) >= 1 {
-			return matches[len(matches)-1] // 마지막 매치 반환
-		}
+// 경비 정책 검사 사용 여부 (기본값 활성화, 카테고리 설정에 policy가 있는 카테고리만 검사)
+func isPolicyCheckEnabled() bool {
+	if os.Getenv("POLICY_CHECK_ENABLED") == "" {
+		return true
 	}
+	return getEnvBool("POLICY_CHECK_ENABLED")
+}
+
+// 사용일 경과 허용 기간 (카테고리 정책에 maxAgeDays가 없을 때 사용, 0 이하면 검사 안 함)
+func getPolicyMaxReceiptAgeDays() int {
+	return getEnvInt("POLICY_MAX_RECEIPT_AGE_DAYS", DEFAULT_POLICY_MAX_RECEIPT_AGE_DAYS)
+}
 
-	// 공통 패턴을 사용하여 날짜 추출
-	for _, pattern := range dateTimePatterns {
-		if matches := pattern.Regex.FindStringSubmatch(dateText); len(matches) > pattern.DayIndex {
-			year := matches[pattern.YearIndex]
-			month := matches[pattern.MonthIndex]
-			day := matches[pattern.DayIndex]
-
-			// 숫자 변환 및 유효성 검사
-			yearInt, _ := strconv.Atoi(year)
-			monthInt, _ := strconv.Atoi(month)
-			dayInt, _ := strconv.Atoi(day)
-
-			// YY 형식을 YYYY로 변환
-			if len(year) == 2 {
-				if yearInt < 50 { // 2000년대로 가정
-					yearInt = 2000 + yearInt
-				} else { // 1900년대로 가정
-					yearInt = 1900 + yearInt
-				}
-				year = strconv.Itoa(yearInt)
-			}
-
-			// 날짜 유효성 검증
-			if !ValidateDateTime(yearInt, monthInt, dayInt, 0, 0, 0) {
-				continue // 유효하지 않은 날짜면 다음 패턴 시도
-			}
-
-			// 월, 일을 2자리로 패딩
-			month = fmt.Sprintf("%02s", month)
-			day = fmt.Sprintf("%02s", day)
-
-			return year + month + day
+// 공휴일 목록 (YYYYMMDD, 쉼표 구분)
+func getPolicyHolidays() []string {
+	return splitEnvList(getEnvString("POLICY_HOLIDAYS", ""))
+}
+
+// 주류 관련 사용처 키워드 (쉼표 구분)
+func getPolicyAlcoholKeywords() []string {
+	return splitEnvList(getEnvString("POLICY_ALCOHOL_KEYWORDS", DEFAULT_POLICY_ALCOHOL_KEYWORDS))
+}
+
+// 면세 사용처 키워드 (쉼표 구분)
+func getTaxExemptKeywords() []string {
+	return splitEnvList(getEnvString("TAX_EXEMPT_KEYWORDS", DEFAULT_TAX_EXEMPT_KEYWORDS))
+}
+
+// 쉼표 구분 목록 분리 (공백 제거, 빈 항목 제외)
+func splitEnvList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
+	return items
+}
 
-	return dateText
+// 환율 파일 경로 (비어 있으면 외화 영수증은 환산하지 않음)
+func getFXRatesFile() string {
+	return getEnvString("FX_RATES_FILE", "")
 }
 
-// 날짜/시간 문자열에서 시간(hour) 추출 (분리된 패턴 사용)
-func extractHourFromDateTime(dateText string) int {
-	if dateText == "" {
-		return -1
-	}
+// 사용일에 환율이 없을 때 직전 고시일 환율을 사용할 수 있는 최대 일수
+func getFXRateMaxStaleDays() int {
+	return getEnvInt("FX_RATE_MAX_STALE_DAYS", DEFAULT_FX_RATE_MAX_STALE_DAYS)
+}
 
-	// 패턴 초기화
-	initializePatterns()
+// 카테고리 설정 파일 경로 (비어 있으면 기본 목록)
+func getCategoryCatalogFile() string {
+	return getEnvString("CATEGORY_CATALOG_FILE", "")
+}
 
-	// 주 패턴에서 시간 추출
-	for _, pattern := range dateTimePatterns {
-		if pattern.HourIndex == -1 {
-			continue // 시간 정보가 없는 패턴은 건너뛰기
-		}
+// 날짜 패턴 설정 파일 경로 (비어 있으면 기본 패턴만 사용)
+func getDateTimePatternsFile() string {
+	return getEnvString("DATETIME_PATTERNS_FILE", "")
+}
 
-		if matches := pattern.Regex.FindStringSubmatch(dateText); len(matches) > pattern.HourIndex {
-			hourStr := matches[pattern.HourIndex]
-			if hour, err := strconv.Atoi(hourStr); err == nil {
+// 사용일이 업로드일보다 늦어도 허용하는 일수 (초과하면 사용일 인식 실패로 처리)
+func getDateFutureToleranceDays() int {
+	return getEnvInt("DATE_FUTURE_TOLERANCE_DAYS", DEFAULT_DATE_FUTURE_TOLERANCE_DAYS)
+}
 
-				// 오후 시간 처리 (12시간 형식)
-				if strings.Contains(dateText, "오후") && hour < 12 {
-					hour += 12
-				}
+// 카테고리 규칙 파일 경로 (비어 있으면 기본 규칙)
+func getCategoryRulesFile() string {
+	return getEnvString("CATEGORY_RULES_FILE", "")
+}
 
-				// 시간 유효성 검사
-				if hour >= 0 && hour <= 23 {
-					return hour
-				}
-			}
-		}
+// Excel 내보내기 시 중복 처리 정책 (off | warn | block)
+func getDuplicateExportPolicy() string {
+	switch policy := strings.ToLower(getEnvString("DUPLICATE_EXPORT_POLICY", DEFAULT_DUPLICATE_EXPORT_POLICY)); policy {
+	case DUPLICATE_POLICY_OFF, DUPLICATE_POLICY_WARN, DUPLICATE_POLICY_BLOCK:
+		return policy
+	default:
+		return DEFAULT_DUPLICATE_EXPORT_POLICY
 	}
+}
 
-	// 간단한 시간 패턴 체크
-	for _, pattern := range simpleTimePatterns {
-		if matches := pattern.Regex.FindStringSubmatch(dateText); len(matches) > pattern.HourIndex {
-			hourStr := matches[pattern.HourIndex]
-			if hour, err := strconv.Atoi(hourStr); err == nil {
-
-				// 오후 시간 처리
-				if strings.Contains(pattern.Description, "오후") && hour < 12 {
-					hour += 12
-				}
-
-				if hour >= 0 && hour <= 23 {
-					return hour
-				}
-			}
-		}
-	}
+func getDuplicateHistoryFile() string {
+	return getEnvString("DUPLICATE_HISTORY_FILE", DEFAULT_DUPLICATE_HISTORY_FILE)
+}
 
-	return -1
+// 이력 보관 기간 (0 이하면 무기한)
+func getDuplicateHistoryDays() int {
+	return getEnvInt("DUPLICATE_HISTORY_DAYS", DEFAULT_DUPLICATE_HISTORY_DAYS)
 }
 
-// 날짜/시간 문자열을 YYYY/MM/DD HH:MM 형식으로 변환
-func formatDateTimeToStandard(dateText string) string {
-	if dateText == "" {
-		return ""
-	}
+// 검토 필요로 표시할 필드 신뢰도 기준 (0 이하면 검토 안 함)
+func getReviewConfidenceThreshold() float64 {
+	return getEnvFloat("REVIEW_CONFIDENCE_THRESHOLD", DEFAULT_REVIEW_CONFIDENCE_THRESHOLD)
+}
 
-	// 패턴 초기화
-	initializePatterns()
-
-	// 주 패턴에서 날짜/시간 추출
-	for _, pattern := range dateTimePatterns {
-		if matches := pattern.Regex.FindStringSubmatch(dateText); len(matches) > pattern.DayIndex {
-			year := matches[pattern.YearIndex]
-			month := matches[pattern.MonthIndex]
-			day := matches[pattern.DayIndex]
-
-			// 숫자 변환
-			yearInt, _ := strconv.Atoi(year)
-			monthInt, _ := strconv.Atoi(month)
-			dayInt, _ := strconv.Atoi(day)
-
-			// YY 형식을 YYYY로 변환
-			if len(year) == 2 {
-				if yearInt < 50 { // 2000년대로 가정
-					yearInt = 2000 + yearInt
-				} else { // 1900년대로 가정
-					yearInt = 1900 + yearInt
-				}
-				year = strconv.Itoa(yearInt)
-			}
-
-			// 날짜 유효성 검증
-			if !ValidateDateTime(yearInt, monthInt, dayInt, 0, 0, 0) {
-				continue // 유효하지 않은 날짜면 다음 패턴 시도
-			}
-
-			// 월, 일을 2자리로 패딩
-			month = fmt.Sprintf("%02d", monthInt)
-			day = fmt.Sprintf("%02d", dayInt)
-
-			result := fmt.Sprintf("%s/%s/%s", year, month, day)
-
-			// 시간 정보가 있는 경우 추가
-			if pattern.HourIndex != -1 && len(matches) > pattern.HourIndex {
-				hourStr := matches[pattern.HourIndex]
-				if hour, err := strconv.Atoi(hourStr); err == nil && hour >= 0 && hour <= 23 {
-					minute := "00" // 기본값
-
-					// 분 정보가 있는 경우
-					if pattern.MinuteIndex != -1 && len(matches) > pattern.MinuteIndex {
-						if minuteStr := matches[pattern.MinuteIndex]; minuteStr != "" {
-							if min, err := strconv.Atoi(minuteStr); err == nil && min >= 0 && min <= 59 {
-								minute = fmt.Sprintf("%02d", min)
-							}
-						}
-					}
-
-					// 오후 시간 처리
-					if strings.Contains(dateText, "오후") && hour < 12 {
-						hour += 12
-					}
-
-					result += fmt.Sprintf(" %02d:%s", hour, minute)
-				}
-			}
-
-			return result
-		}
-	}
+func getReviewQueueFile() string {
+	return getEnvString("REVIEW_QUEUE_FILE", DEFAULT_REVIEW_QUEUE_FILE)
+}
 
-	// 간단한 시간만 있는 패턴 처리 (현재 날짜 기준)
-	for _, pattern := range simpleTimePatterns {
-		if matches := pattern.Regex.FindStringSubmatch(dateText); len(matches) > pattern.HourIndex {
-			hourStr := matches[pattern.HourIndex]
-			if hour, err := strconv.Atoi(hourStr); err == nil && hour >= 0 && hour <= 23 {
-				// 현재 날짜 사용
-				now := time.Now()
-				result := fmt.Sprintf("%04d/%02d/%02d", now.Year(), now.Month(), now.Day())
-
-				minute := "00"
-				// 분 정보 추출 시도
-				if minuteMatch := regexp.MustCompile(`(\d{1,2}):(\d{1,2})`).FindStringSubmatch(dateText); len(minuteMatch) >= 3 {
-					if min, err := strconv.Atoi(minuteMatch[2]); err == nil && min >= 0 && min <= 59 {
-						minute = fmt.Sprintf("%02d", min)
-					}
-				}
-
-				// 오후 시간 처리
-				if strings.Contains(pattern.Description, "오후") && hour < 12 {
-					hour += 12
-				}
-
-				result += fmt.Sprintf(" %02d:%s", hour, minute)
-				return result
-			}
-		}
+// 검토 완료 항목 보관 기간 (0 이하면 무기한)
+func getReviewRetentionDays() int {
+	return getEnvInt("REVIEW_RETENTION_DAYS", DEFAULT_REVIEW_RETENTION_DAYS)
+}
+
+// Excel 내보내기 시 미검토 항목 처리 정책 (off | warn | block)
+func getReviewExportPolicy() string {
+	switch policy := strings.ToLower(getEnvString("REVIEW_EXPORT_POLICY", DEFAULT_REVIEW_EXPORT_POLICY)); policy {
+	case REVIEW_POLICY_OFF, REVIEW_POLICY_WARN, REVIEW_POLICY_BLOCK:
+		return policy
+	default:
+		return DEFAULT_REVIEW_EXPORT_POLICY
 	}
+}
+
+// OCR 제공자 선택 (clova | local)
+func getOCRProviderName() string {
+	return strings.ToLower(getEnvString("OCR_PROVIDER", DEFAULT_OCR_PROVIDER))
+}
+
+func getLocalOCRURL() string {
+	return getEnvString("LOCAL_OCR_URL", DEFAULT_LOCAL_OCR_URL)
+}
+
+// 내장 Mock OCR 서버 설정 (오프라인 개발/테스트용)
+func isMockOCREnabled() bool {
+	return getEnvBool("MOCK_OCR_ENABLED")
+}
+
+func getMockOCRFixtureDir() string {
+	return getEnvString("MOCK_OCR_FIXTURE_DIR", DEFAULT_MOCK_FIXTURE)
+}
 
-	// 변환할 수 없는 경우 원본 반환
-	return dateText
+// OCR 응답 녹화/재생 설정 (off | record | replay)
+func getOCRRecordMode() string {
+	return strings.ToLower(getEnvString("OCR_RECORD_MODE", OCR_RECORD_MODE_OFF))
+}
+
+func getOCRRecordDir() string {
+	return getEnvString("OCR_RECORD_DIR", DEFAULT_OCR_RECORD_DIR)
+}
+
+func isOCRVerboseLog() bool {
+	return getEnvBool("OCR_VERBOSE_LOG")
+}
+
+// 서버 설정
+func getServerPort() string {
+	port := getEnvString("SERVER_PORT", DEFAULT_SERVER_PORT)
+	return ":" + port
+}
+
+func getUploadLimitMB() int {
+	return getEnvInt("UPLOAD_LIMIT_MB", DEFAULT_UPLOAD_LIMIT_MB)
+}
+
+// 한 번에 처리할 최대 이미지 수 (다중 페이지 PDF/TIFF는 페이지 수로 계산)
+func getMaxFiles() int {
+	return getEnvInt("MAX_FILES", DEFAULT_MAX_FILES)
+}
+
+// 기본값 설정들
+func getDefaultDepositorDC() string {
+	return getEnvString("DEFAULT_DEPOSITOR_DC", "")
+}
+
+func getDefaultDeptCD() string {
+	return getEnvString("DEFAULT_DEPT_CD", "")
+}
+
+func getDefaultEmpCD() string {
+	return getEnvString("DEFAULT_EMP_CD", "")
+}
+
+func getDefaultBankCD() string {
+	return getEnvString("DEFAULT_BANK_CD", "")
+}
+
+func getDefaultBANB() string {
+	return getEnvString("DEFAULT_BA_NB", "")
+}
+
+func getDefaultAttrCD() string {
+	return getEnvString("DEFAULT_ATTR_CD", DEFAULT_ATTR_CD)
 }
@@ -0,0 +1,487 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 날짜/시간 구성 요소
+const (
+	DATE_COMPONENT_YEAR   = "year"
+	DATE_COMPONENT_MONTH  = "month"
+	DATE_COMPONENT_DAY    = "day"
+	DATE_COMPONENT_HOUR   = "hour"
+	DATE_COMPONENT_MINUTE = "minute"
+	DATE_COMPONENT_SECOND = "second"
+)
+
+// 파싱 신뢰도 (0~1)
+const (
+	DATE_CONFIDENCE_FULL       = 1.0 // 4자리 연도 + 월 + 일
+	DATE_CONFIDENCE_SHORT_YEAR = 0.9 // 2자리 연도 (세기 추정)
+	DATE_CONFIDENCE_COMPACT    = 0.8 // 구분 기호 없는 YYYYMMDD (다른 숫자일 수 있음)
+	DATE_CONFIDENCE_NO_YEAR    = 0.7 // 연도 없음 (청구 기간/업로드일로 추론)
+	DATE_CONFIDENCE_TIME_ONLY  = 0.3 // 시간만 있음 (날짜는 오늘로 추정)
+	DATE_GUESSED_BELOW         = 0.5 // 이 값 미만이면 추정된 날짜로 표시
+)
+
+// 2자리 연도 추론 시 허용하는 최대 과거 연수 (YY 후보는 100년 간격이므로 하나만 남음)
+const DATE_INFER_MAX_PAST_YEARS = 50
+
+// ParsedDateTime 날짜/시간 파싱 결과
+type ParsedDateTime struct {
+	Time       time.Time
+	Pattern    string   // 일치한 DateTimePattern.Description
+	Components []string // 원문에 실제로 있던 구성 요소
+	Inferred   []string // 원문에 없거나 불완전해 추론한 구성 요소 (검토 필요)
+	Confidence float64
+}
+
+// DateTimeContext 날짜 추론 기준 (2자리/누락 연도, 시간만 있는 영수증)
+type DateTimeContext struct {
+	Reference   time.Time // 업로드 일시 (비어 있으면 현재 시각)
+	PeriodStart time.Time // 청구 기간 시작일 (선택)
+	PeriodEnd   time.Time // 청구 기간 종료일 (선택, 해당 일 포함)
+}
+
+// DateTimeParseInfo 파싱 근거 (API 응답용)
+type DateTimeParseInfo struct {
+	Pattern    string   `json:"pattern,omitempty"`
+	Components []string `json:"components"`
+	Inferred   []string `json:"inferred,omitempty"` // 추론한 구성 요소 (year, month, day)
+	Confidence float64  `json:"confidence"`
+	Guessed    bool     `json:"guessed"` // 날짜를 원문에서 읽지 못하고 추정함
+}
+
+// DateTimePatternTestResult 패턴별 테스트 결과 (패턴 테스트 API용)
+type DateTimePatternTestResult struct {
+	Pattern    string   `json:"pattern"`
+	Kind       string   `json:"kind"`
+	Priority   int      `json:"priority"`
+	Matched    bool     `json:"matched"`
+	MatchText  string   `json:"matchText,omitempty"`
+	Result     string   `json:"result,omitempty"` // YYYY/MM/DD[ HH:MM]
+	Components []string `json:"components,omitempty"`
+	Inferred   []string `json:"inferred,omitempty"`
+	Confidence float64  `json:"confidence,omitempty"`
+	Selected   bool     `json:"selected"` // ParseDateTime이 사용하는 결과
+	Reason     string   `json:"reason,omitempty"`
+}
+
+// 날짜/시간 문자열 파싱 (현재 시각 기준으로 추론)
+func ParseDateTime(text string) (*ParsedDateTime, error) {
+	return ParseDateTimeWithContext(text, DateTimeContext{})
+}
+
+// 날짜/시간 문자열 파싱 (업로드일/청구 기간 기준으로 추론)
+func ParseDateTimeWithContext(text string, ctx DateTimeContext) (*ParsedDateTime, error) {
+	return currentDateTimePatternSet().Parse(text, ctx)
+}
+
+// 추론 기준 생성 (청구 기간은 YYYY-MM-DD 또는 YYYYMMDD, 둘 다 비어 있으면 사용 안 함)
+func NewDateTimeContext(reference time.Time, periodStart, periodEnd string) (DateTimeContext, error) {
+	ctx := DateTimeContext{Reference: reference}
+	periodStart, periodEnd = strings.TrimSpace(periodStart), strings.TrimSpace(periodEnd)
+	if periodStart == "" && periodEnd == "" {
+		return ctx, nil
+	}
+	if periodStart == "" || periodEnd == "" {
+		return ctx, fmt.Errorf("청구 기간은 시작일과 종료일을 함께 입력해야 합니다")
+	}
+
+	var err error
+	if ctx.PeriodStart, err = parseDateInput(periodStart); err != nil {
+		return ctx, fmt.Errorf("청구 기간 시작일 형식 오류 %q (YYYY-MM-DD)", periodStart)
+	}
+	if ctx.PeriodEnd, err = parseDateInput(periodEnd); err != nil {
+		return ctx, fmt.Errorf("청구 기간 종료일 형식 오류 %q (YYYY-MM-DD)", periodEnd)
+	}
+	if ctx.PeriodEnd.Before(ctx.PeriodStart) {
+		return ctx, fmt.Errorf("청구 기간 종료일(%s)이 시작일(%s)보다 빠릅니다", periodEnd, periodStart)
+	}
+	return ctx, nil
+}
+
+// 결과가 업로드될 때의 추론 기준 (업로드 일시가 없으면 fallback)
+func resultDateContext(result OCRResult, fallback DateTimeContext) DateTimeContext {
+	return uploadDateContext(result.UploadedAt, result.ClaimPeriodStart, result.ClaimPeriodEnd, fallback)
+}
+
+// 저장해 둔 업로드 일시/청구 기간으로 추론 기준 복원 (업로드 일시가 없으면 fallback)
+func uploadDateContext(uploadedAt *time.Time, periodStart, periodEnd string, fallback DateTimeContext) DateTimeContext {
+	if uploadedAt == nil || uploadedAt.IsZero() {
+		return fallback
+	}
+	ctx, err := NewDateTimeContext(*uploadedAt, periodStart, periodEnd)
+	if err != nil {
+		log.Printf("⚠️ 저장된 청구 기간 무시 (%s ~ %s): %v", periodStart, periodEnd, err)
+		return DateTimeContext{Reference: *uploadedAt}
+	}
+	return ctx
+}
+
+// 입력 날짜 파싱 (YYYY-MM-DD 또는 YYYYMMDD)
+func parseDateInput(text string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		if date, err := time.ParseInLocation(layout, text, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("날짜 형식 오류: %q", text)
+}
+
+// 우선순위 순으로 패턴을 적용해 첫 번째 결과 반환 (허용 오차를 넘는 미래 날짜는 거부)
+func (s *DateTimePatternSet) Parse(text string, ctx DateTimeContext) (*ParsedDateTime, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("날짜/시간이 비어 있습니다")
+	}
+
+	for _, pattern := range s.dateTimes {
+		if parsed := pattern.parse(text, ctx); parsed != nil {
+			if err := ctx.checkFuture(parsed); err != nil {
+				return nil, err
+			}
+			return parsed, nil
+		}
+	}
+	for _, pattern := range s.times {
+		if parsed := pattern.parse(text, ctx); parsed != nil {
+			return parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("날짜/시간 형식을 인식할 수 없습니다: %q", text)
+}
+
+// 모든 패턴을 적용한 결과 (우선순위 순, 선택된 결과 표시)
+func (s *DateTimePatternSet) Test(text string, ctx DateTimeContext) []DateTimePatternTestResult {
+	text = strings.TrimSpace(text)
+	results := make([]DateTimePatternTestResult, 0, len(s.dateTimes)+len(s.times))
+	selected := false
+
+	record := func(result DateTimePatternTestResult, matchText string, parsed *ParsedDateTime, format func() string) {
+		result.MatchText = matchText
+		switch {
+		case matchText == "" && parsed == nil:
+			result.Reason = "정규식과 일치하지 않음"
+		case parsed == nil:
+			result.Matched = true
+			result.Reason = "유효하지 않은 날짜/시간"
+		default:
+			result.Matched = true
+			result.Result = format()
+			result.Components = parsed.Components
+			result.Inferred = parsed.Inferred
+			result.Confidence = parsed.Confidence
+			// ParseDateTime과 같이 첫 결과가 미래 날짜면 선택된 결과 없음
+			if err := ctx.checkFuture(parsed); err != nil {
+				result.Reason = err.Error()
+			} else {
+				result.Selected = !selected
+			}
+			selected = true
+		}
+		results = append(results, result)
+	}
+
+	for _, pattern := range s.dateTimes {
+		parsed := pattern.parse(text, ctx)
+		record(DateTimePatternTestResult{Pattern: pattern.Description, Kind: DATETIME_PATTERN_KIND_DATETIME, Priority: pattern.Priority},
+			pattern.Regex.FindString(text), parsed, func() string { return parsed.Standard() })
+	}
+	for _, pattern := range s.times {
+		parsed := pattern.parse(text, ctx)
+		record(DateTimePatternTestResult{Pattern: pattern.Description, Kind: DATETIME_PATTERN_KIND_TIME, Priority: pattern.Priority},
+			pattern.Regex.FindString(text), parsed, func() string { return parsed.Time.Format("15:04") })
+	}
+	return results
+}
+
+// 날짜 패턴 적용 (일치하지 않거나 유효하지 않은 날짜면 nil)
+func (p DateTimePattern) parse(text string, ctx DateTimeContext) *ParsedDateTime {
+	indexes := p.Regex.FindStringSubmatchIndex(text)
+	if indexes == nil {
+		return nil
+	}
+	group := func(index int) string {
+		if index <= 0 || 2*index+1 >= len(indexes) || indexes[2*index] < 0 {
+			return ""
+		}
+		return text[indexes[2*index]:indexes[2*index+1]]
+	}
+
+	month, errMonth := strconv.Atoi(group(p.MonthIndex))
+	day, errDay := strconv.Atoi(group(p.DayIndex))
+	if errMonth != nil || errDay != nil {
+		return nil
+	}
+
+	confidence := p.Confidence
+	if confidence == 0 {
+		confidence = DATE_CONFIDENCE_FULL
+	}
+
+	components := []string{DATE_COMPONENT_YEAR, DATE_COMPONENT_MONTH, DATE_COMPONENT_DAY}
+	var inferred []string
+	yearText := group(p.YearIndex)
+
+	// YY 패턴이 4자리 연도의 뒷부분과 일치한 경우 앞 두 자리를 세기로 사용
+	if len(yearText) == 2 {
+		if start := indexes[2*p.YearIndex]; start >= 2 && isASCIIDigits(text[start-2:start]) {
+			yearText = text[start-2:start] + yearText
+		}
+	}
+
+	var year int
+	switch {
+	case yearText == "":
+		// 연도 없음: 청구 기간 또는 업로드일 기준으로 추론
+		reference := ctx.reference()
+		candidates := []int{reference.Year(), reference.Year() - 1}
+		if ctx.hasPeriod() {
+			candidates = append([]int{ctx.PeriodEnd.Year(), ctx.PeriodStart.Year()}, candidates...)
+		}
+		var ok bool
+		if year, ok = ctx.inferYear(month, day, candidates); !ok {
+			return nil
+		}
+		components = components[1:]
+		inferred = append(inferred, DATE_COMPONENT_YEAR)
+		confidence = min(confidence, DATE_CONFIDENCE_NO_YEAR)
+	case len(yearText) == 2:
+		// YY 형식: 업로드일 이전 중 가장 최근 세기 선택 (청구 기간이 있으면 우선)
+		yy, err := strconv.Atoi(yearText)
+		if err != nil {
+			return nil
+		}
+		century := ctx.reference().Year() / 100 * 100
+		var ok bool
+		if year, ok = ctx.inferYear(month, day, []int{century + yy, century - 100 + yy}); !ok {
+			return nil
+		}
+		inferred = append(inferred, DATE_COMPONENT_YEAR)
+		confidence = min(confidence, DATE_CONFIDENCE_SHORT_YEAR)
+	default:
+		var err error
+		if year, err = strconv.Atoi(yearText); err != nil {
+			return nil
+		}
+	}
+
+	// 날짜 유효성 검증
+	if !ValidateDateTime(year, month, day, 0, 0, 0) {
+		return nil
+	}
+
+	parsed := &ParsedDateTime{
+		Pattern:    p.Description,
+		Components: components,
+		Inferred:   inferred,
+		Confidence: confidence,
+	}
+
+	// 시간 정보가 있으면 추가 (유효하지 않은 시간은 무시하고 날짜만 사용)
+	hour, minute, second := 0, 0, 0
+	if hourText := group(p.HourIndex); hourText != "" {
+		hour, _ = strconv.Atoi(hourText)
+		minute, _ = strconv.Atoi(group(p.MinuteIndex))
+		second, _ = strconv.Atoi(group(p.SecondIndex))
+
+		// 오전/오후 처리 (12시간 형식)
+		if p.MeridiemIndex > 0 {
+			hour = applyMeridiem(hour, group(p.MeridiemIndex))
+		}
+
+		if ValidateDateTime(year, month, day, hour, minute, second) {
+			parsed.Components = append(parsed.Components, DATE_COMPONENT_HOUR)
+			if group(p.MinuteIndex) != "" {
+				parsed.Components = append(parsed.Components, DATE_COMPONENT_MINUTE)
+			}
+			if group(p.SecondIndex) != "" {
+				parsed.Components = append(parsed.Components, DATE_COMPONENT_SECOND)
+			}
+		} else {
+			hour, minute, second = 0, 0, 0
+		}
+	}
+
+	parsed.Time = time.Date(year, time.Month(month), day, hour, minute, second, 0, time.Local)
+	return parsed
+}
+
+// 시간만 있는 패턴 적용 (날짜는 업로드일, 업로드 시각보다 늦으면 전날로 추론)
+func (p SimpleTimePattern) parse(text string, ctx DateTimeContext) *ParsedDateTime {
+	matches := p.Regex.FindStringSubmatch(text)
+	if len(matches) <= p.HourIndex {
+		return nil
+	}
+	hour, err := strconv.Atoi(matches[p.HourIndex])
+	if err != nil {
+		return nil
+	}
+	if p.MeridiemIndex > 0 && p.MeridiemIndex < len(matches) {
+		hour = applyMeridiem(hour, matches[p.MeridiemIndex])
+	}
+	if hour < 0 || hour > 23 {
+		return nil
+	}
+
+	components := []string{DATE_COMPONENT_HOUR}
+	minute := 0
+	if p.MinuteIndex > 0 && p.MinuteIndex < len(matches) && matches[p.MinuteIndex] != "" {
+		if value, err := strconv.Atoi(matches[p.MinuteIndex]); err == nil && value >= 0 && value <= 59 {
+			minute = value
+			components = append(components, DATE_COMPONENT_MINUTE)
+		}
+	}
+
+	reference := ctx.reference()
+	issuedAt := time.Date(reference.Year(), reference.Month(), reference.Day(), hour, minute, 0, 0, time.Local)
+	if issuedAt.After(reference) {
+		issuedAt = issuedAt.AddDate(0, 0, -1)
+	}
+
+	return &ParsedDateTime{
+		Time:       issuedAt,
+		Pattern:    p.Description,
+		Components: components,
+		Inferred:   []string{DATE_COMPONENT_YEAR, DATE_COMPONENT_MONTH, DATE_COMPONENT_DAY},
+		Confidence: DATE_CONFIDENCE_TIME_ONLY,
+	}
+}
+
+// 추론 기준 시각 (업로드 일시, 없으면 현재 시각)
+func (ctx DateTimeContext) reference() time.Time {
+	if ctx.Reference.IsZero() {
+		return time.Now()
+	}
+	return ctx.Reference
+}
+
+// 허용되는 가장 늦은 사용일 (업로드일 + DATE_FUTURE_TOLERANCE_DAYS)
+func (ctx DateTimeContext) latestDate() time.Time {
+	reference := ctx.reference()
+	return time.Date(reference.Year(), reference.Month(), reference.Day()+getDateFutureToleranceDays(), 0, 0, 0, 0, time.Local)
+}
+
+func (ctx DateTimeContext) hasPeriod() bool {
+	return !ctx.PeriodStart.IsZero() && !ctx.PeriodEnd.IsZero()
+}
+
+// 연도 후보 중 선택 (청구 기간에 포함되는 날짜 우선, 없으면 허용 범위 내 가장 최근 날짜)
+// 업로드일 기준 DATE_INFER_MAX_PAST_YEARS년보다 오래된 후보는 제외하고, 모두 미래면
+// 가장 가까운 후보를 반환해 미래 날짜로 거부되도록 합니다.
+func (ctx DateTimeContext) inferYear(month, day int, candidates []int) (int, bool) {
+	latest := ctx.latestDate()
+	earliest := ctx.reference().AddDate(-DATE_INFER_MAX_PAST_YEARS, 0, 0)
+	best, future := 0, 0
+	for _, year := range candidates {
+		if !ValidateDateTime(year, month, day, 0, 0, 0) {
+			continue
+		}
+		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+		if ctx.hasPeriod() && !date.Before(ctx.PeriodStart) && !date.After(ctx.PeriodEnd) {
+			return year, true
+		}
+		switch {
+		case date.Before(earliest):
+		case date.After(latest):
+			if future == 0 || year < future {
+				future = year
+			}
+		case year > best:
+			best = year
+		}
+	}
+	if best == 0 {
+		best = future
+	}
+	return best, best != 0
+}
+
+// 허용 오차를 넘는 미래 날짜 거부
+func (ctx DateTimeContext) checkFuture(parsed *ParsedDateTime) error {
+	date := time.Date(parsed.Time.Year(), parsed.Time.Month(), parsed.Time.Day(), 0, 0, 0, 0, time.Local)
+	if date.After(ctx.latestDate()) {
+		return fmt.Errorf("사용일 %s이(가) 업로드일 %s보다 미래입니다", date.Format("2006/01/02"), ctx.reference().Format("2006/01/02"))
+	}
+	return nil
+}
+
+// 12시간 형식을 24시간으로 변환 (오전 12시 = 0시, 오후 1~11시 = 13~23시, 맞지 않으면 -1)
+func applyMeridiem(hour int, meridiem string) int {
+	switch strings.ToUpper(meridiem) {
+	case "오전", "AM":
+		if hour == 12 {
+			return 0
+		}
+		if hour > 12 {
+			return -1
+		}
+	case "오후", "PM":
+		if hour >= 1 && hour < 12 {
+			return hour + 12
+		}
+		if hour == 0 {
+			return -1
+		}
+		// "오후 19:30"처럼 24시간 형식이 함께 쓰인 경우는 그대로 사용
+	}
+	return hour
+}
+
+// 구성 요소가 원문에 있었는지 확인
+func (p *ParsedDateTime) Has(component string) bool {
+	return containsString(p.Components, component)
+}
+
+// 원문에 날짜가 있었는지 여부
+func (p *ParsedDateTime) HasDate() bool {
+	return p.Has(DATE_COMPONENT_DAY)
+}
+
+// 원문에 시간이 있었는지 여부
+func (p *ParsedDateTime) HasTime() bool {
+	return p.Has(DATE_COMPONENT_HOUR)
+}
+
+// 추정된 날짜 여부
+func (p *ParsedDateTime) Guessed() bool {
+	return p.Confidence < DATE_GUESSED_BELOW
+}
+
+// YYYYMMDD 형식
+func (p *ParsedDateTime) YYYYMMDD() string {
+	return p.Time.Format("20060102")
+}
+
+// YYYY/MM/DD HH:MM 형식 (시간이 없으면 YYYY/MM/DD)
+func (p *ParsedDateTime) Standard() string {
+	if p.HasTime() {
+		return p.Time.Format("2006/01/02 15:04")
+	}
+	return p.Time.Format("2006/01/02")
+}
+
+// API 응답용 파싱 근거
+func (p *ParsedDateTime) Info() *DateTimeParseInfo {
+	return &DateTimeParseInfo{
+		Pattern:    p.Pattern,
+		Components: p.Components,
+		Inferred:   p.Inferred,
+		Confidence: p.Confidence,
+		Guessed:    p.Guessed(),
+	}
+}
+
+func isASCIIDigits(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] < '0' || text[i] > '9' {
+			return false
+		}
+	}
+	return text != ""
+}
@@ -1,251 +1,402 @@
 package main
 
-import "regexp"
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 패턴 종류
+const (
+	DATETIME_PATTERN_KIND_DATETIME = "datetime" // 날짜 (시간은 선택)
+	DATETIME_PATTERN_KIND_TIME     = "time"     // 시간만 (날짜는 기준일)
+)
 
 // DateTimePattern 날짜/시간 패턴 정의 구조체
 type DateTimePattern struct {
-	Regex       *regexp.Regexp
-	Description string
-	YearIndex   int // 년도 그룹 인덱스
-	MonthIndex  int // 월 그룹 인덱스
-	DayIndex    int // 일 그룹 인덱스
-	HourIndex   int // 시간 그룹 인덱스 (-1이면 없음)
-	MinuteIndex int // 분 그룹 인덱스 (-1이면 없음)
-	SecondIndex int // 초 그룹 인덱스 (-1이면 없음)
+	Regex         *regexp.Regexp
+	Description   string
+	Priority      int     // 작을수록 먼저 시도
+	YearIndex     int     // 년도 그룹 인덱스
+	MonthIndex    int     // 월 그룹 인덱스
+	DayIndex      int     // 일 그룹 인덱스
+	HourIndex     int     // 시간 그룹 인덱스 (0이면 없음)
+	MinuteIndex   int     // 분 그룹 인덱스 (0이면 없음)
+	SecondIndex   int     // 초 그룹 인덱스 (0이면 없음)
+	MeridiemIndex int     // 오전/오후(AM/PM) 그룹 인덱스 (0이면 없음, 24시간 형식)
+	Confidence    float64 // 파싱 신뢰도 (0이면 DATE_CONFIDENCE_FULL)
 }
 
 // SimpleTimePattern 간단한 시간 패턴 (시간만 있는 경우)
 type SimpleTimePattern struct {
-	Regex       *regexp.Regexp
-	Description string
-	HourIndex   int
+	Regex         *regexp.Regexp
+	Description   string
+	Priority      int
+	HourIndex     int
+	MinuteIndex   int // 분 그룹 인덱스 (0이면 없음)
+	MeridiemIndex int // 오전/오후 그룹 인덱스 (0이면 없음)
+}
+
+// DateTimePatternDefinition 패턴 정의 (DATETIME_PATTERNS_FILE 항목)
+type DateTimePatternDefinition struct {
+	Description string                  `json:"description"` // 패턴 이름 (기본 패턴과 같으면 대체)
+	Kind        string                  `json:"kind,omitempty"`
+	Regex       string                  `json:"regex"`
+	Priority    int                     `json:"priority"`
+	Groups      DateTimePatternGroups   `json:"groups"`
+	Confidence  float64                 `json:"confidence,omitempty"`
+	Samples     []DateTimePatternSample `json:"samples"` // 시작 시 검증에 사용
+	Disabled    bool                    `json:"disabled,omitempty"`
+}
+
+// DateTimePatternGroups 정규식 그룹 인덱스 (0이면 없음)
+type DateTimePatternGroups struct {
+	Year     int `json:"year,omitempty"`
+	Month    int `json:"month,omitempty"`
+	Day      int `json:"day,omitempty"`
+	Hour     int `json:"hour,omitempty"`
+	Minute   int `json:"minute,omitempty"`
+	Second   int `json:"second,omitempty"`
+	Meridiem int `json:"meridiem,omitempty"`
+}
+
+// DateTimePatternSample 샘플 입력과 기대 결과 (datetime: "YYYY/MM/DD[ HH:MM]", time: "HH:MM")
+// 연도가 없거나 2자리인 샘플은 dateTimePatternSampleContext 기준으로 연도를 추론합니다.
+type DateTimePatternSample struct {
+	Input  string `json:"input"`
+	Expect string `json:"expect"`
+}
+
+// DateTimePatternFile 패턴 설정 파일 형식
+type DateTimePatternFile struct {
+	Patterns []DateTimePatternDefinition `json:"patterns"`
+}
+
+// DateTimePatternSet 우선순위 순으로 정렬된 패턴 목록
+type DateTimePatternSet struct {
+	definitions []DateTimePatternDefinition
+	dateTimes   []DateTimePattern
+	times       []SimpleTimePattern
+	source      string
+}
+
+// 한국어 날짜 표기 조각
+const (
+	koreanDatePattern    = `(\d{4}|\d{2})년\s*(\d{1,2})월\s*(\d{1,2})일`
+	numericDatePattern   = `(\d{4}|\d{2})[./-]\s*(\d{1,2})[./-]\s*(\d{1,2})\.?`
+	weekdaySuffixPattern = `(?:\s*\(?[월화수목금토일](?:요일)?\)?)` // "(화)", "화요일"
+	meridiemPattern      = `(오전|오후|[AaPp][Mm])`
+)
+
+// 샘플 검증 기준 (2024/12/31 업로드, 청구 기간 없음)
+var dateTimePatternSampleContext = DateTimeContext{Reference: time.Date(2024, 12, 31, 23, 59, 59, 0, time.Local)}
+
+var (
+	dateTimePatternSet     *DateTimePatternSet
+	dateTimePatternSetOnce sync.Once
+	dateTimePatternSetErr  error
+)
+
+// 기본 패턴 정의 (자주 사용되는 패턴을 앞에)
+func defaultDateTimePatternDefinitions() []DateTimePatternDefinition {
+	noYear := DateTimePatternGroups{Month: 1, Day: 2, Hour: 3, Minute: 4, Second: 5}
+	full := DateTimePatternGroups{Year: 1, Month: 2, Day: 3, Hour: 4, Minute: 5, Second: 6}
+	withMinute := DateTimePatternGroups{Year: 1, Month: 2, Day: 3, Hour: 4, Minute: 5}
+	dateOnly := DateTimePatternGroups{Year: 1, Month: 2, Day: 3}
+	withMeridiem := DateTimePatternGroups{Year: 1, Month: 2, Day: 3, Meridiem: 4, Hour: 5, Minute: 6, Second: 7}
+	trailingMeridiem := DateTimePatternGroups{Year: 1, Month: 2, Day: 3, Hour: 4, Minute: 5, Second: 6, Meridiem: 7}
+	noYearMeridiem := DateTimePatternGroups{Month: 1, Day: 2, Meridiem: 3, Hour: 4, Minute: 5}
+
+	return []DateTimePatternDefinition{
+		// 이미 변환된 날짜 - 구분 기호 없는 YYYYMMDD (시간은 선택)
+		{Description: "YYYYMMDD", Priority: 0, Groups: full, Confidence: DATE_CONFIDENCE_COMPACT,
+			Regex:   `(\d{4})(\d{2})(\d{2})(?:\s*(\d{1,2}):(\d{2})(?::(\d{2}))?)?`,
+			Samples: []DateTimePatternSample{{"20240305", "2024/03/05"}, {"2024030519:30:12", "2024/03/05 19:30"}}},
+		// 한국어 표기
+		{Description: "YYYY년 M월 D일 오전/오후 H시 M분", Priority: 1, Groups: withMeridiem,
+			Regex:   koreanDatePattern + weekdaySuffixPattern + `?\s*` + meridiemPattern + `?\s*(\d{1,2})시(?:\s*(\d{1,2})분)?(?:\s*(\d{1,2})초)?`,
+			Samples: []DateTimePatternSample{{"2024년 3월 5일 오후 7시 30분", "2024/03/05 19:30"}, {"2024년 3월 5일 (화) 오전 12시 10분", "2024/03/05 00:10"}}},
+		{Description: "YYYY년 M월 D일 오전/오후 HH:MM", Priority: 2, Groups: withMeridiem,
+			Regex:   koreanDatePattern + weekdaySuffixPattern + `?\s*` + meridiemPattern + `?\s*(\d{1,2}):(\d{2})(?::(\d{2}))?`,
+			Samples: []DateTimePatternSample{{"2024년 3월 5일 화요일 오후 12:05:33", "2024/03/05 12:05"}}},
+		{Description: "YYYY년 M월 D일", Priority: 3, Groups: dateOnly,
+			Regex:   koreanDatePattern,
+			Samples: []DateTimePatternSample{{"2024년 3월 5일", "2024/03/05"}, {"24년03월05일", "2024/03/05"}}},
+		// 요일/오전·오후가 붙은 숫자 표기
+		{Description: "YYYY.MM.DD(요일) 오전/오후 HH:MM", Priority: 4, Groups: withMeridiem,
+			Regex:   numericDatePattern + weekdaySuffixPattern + `(?:\s*` + meridiemPattern + `?\s*(\d{1,2}):(\d{2})(?::(\d{2}))?)?`,
+			Samples: []DateTimePatternSample{{"2024.03.05(화) 오후 7:30", "2024/03/05 19:30"}, {"2024-03-05 (화) 19:30:12", "2024/03/05 19:30"}}},
+		{Description: "YYYY.MM.DD 오전/오후 HH:MM", Priority: 5, Groups: withMeridiem,
+			Regex:   numericDatePattern + `\s*` + meridiemPattern + `\s*(\d{1,2}):(\d{2})(?::(\d{2}))?`,
+			Samples: []DateTimePatternSample{{"2024.03.05 오전 12:30", "2024/03/05 00:30"}, {"2024-03-05 PM 7:30", "2024/03/05 19:30"}}},
+		{Description: "YYYY.MM.DD HH:MM AM/PM", Priority: 5, Groups: trailingMeridiem,
+			Regex:   numericDatePattern + `\s*(\d{1,2}):(\d{2})(?::(\d{2}))?\s*` + meridiemPattern + `(?:[^A-Za-z]|$)`,
+			Samples: []DateTimePatternSample{{"2024.03.05 12:30 PM", "2024/03/05 12:30"}, {"2024-03-05 7:30:12pm", "2024/03/05 19:30"}}},
+		// 숫자 표기
+		{Description: "YYYY.MM.DDHH:MM:SS", Priority: 6, Groups: full,
+			Regex:   `(\d{4})\.(\d{2})\.(\d{2})(\d{2}):(\d{2}):(\d{2})`,
+			Samples: []DateTimePatternSample{{"2024.03.0519:30:12", "2024/03/05 19:30"}}},
+		{Description: "YYYY.MM.DDHH:MM", Priority: 7, Groups: withMinute,
+			Regex:   `(\d{4})\.(\d{2})\.(\d{2})(\d{2}):(\d{2})`,
+			Samples: []DateTimePatternSample{{"2024.03.0519:30", "2024/03/05 19:30"}}},
+		{Description: "YY.MM.DD HH:MM:SS", Priority: 8, Groups: full,
+			Regex:   `(\d{2})\.(\d{2})\.(\d{2})\s+(\d{1,2}):\s*(\d{1,2}):\s*(\d{1,2})`,
+			Samples: []DateTimePatternSample{{"24.03.04 12:15:40", "2024/03/04 12:15"}, {"2024.03.05 19:30:12", "2024/03/05 19:30"}}},
+		{Description: "YY.MM.DD HH:MM", Priority: 9, Groups: withMinute,
+			Regex:   `(\d{2})\.(\d{2})\.(\d{2})\s+(\d{1,2}):\s*(\d{1,2})`,
+			Samples: []DateTimePatternSample{{"24.03.04 12:15", "2024/03/04 12:15"}}},
+		{Description: "YY.MM.DD", Priority: 10, Groups: dateOnly,
+			Regex:   `^(\d{2})\.(\d{2})\.(\d{2})$`,
+			Samples: []DateTimePatternSample{{"24.03.04", "2024/03/04"}}},
+		{Description: "YYYY. M. D. HH:MM:SS", Priority: 11, Groups: full,
+			Regex:   `(\d{4})\.\s*(\d{1,2})\.\s*(\d{1,2})\.\s*(\d{1,2}):\s*(\d{1,2}):\s*(\d{1,2})`,
+			Samples: []DateTimePatternSample{{"2024. 3. 6. 08:05:11", "2024/03/06 08:05"}}},
+		{Description: "YYYY. M. D. HH:MM", Priority: 12, Groups: withMinute,
+			Regex:   `(\d{4})\.\s*(\d{1,2})\.\s*(\d{1,2})\.\s*(\d{1,2}):\s*(\d{1,2})`,
+			Samples: []DateTimePatternSample{{"2024. 3. 6. 08:05", "2024/03/06 08:05"}}},
+		{Description: "YYYY.MM.DD", Priority: 13, Groups: dateOnly,
+			Regex:   `(\d{4})\.(\d{2})\.(\d{2})`,
+			Samples: []DateTimePatternSample{{"2024.03.06", "2024/03/06"}}},
+		{Description: "YYYY. M. D", Priority: 14, Groups: dateOnly,
+			Regex:   `(\d{4})\.\s*(\d{1,2})\.\s*(\d{1,2})`,
+			Samples: []DateTimePatternSample{{"2024. 3. 6", "2024/03/06"}}},
+		{Description: "YY.MM.DD I HH:MM:SS", Priority: 15, Groups: full,
+			Regex:   `(\d{2})\.(\d{2})\.(\d{2})\s+[I|]\s+(\d{1,2}):\s*(\d{1,2}):\s*(\d{1,2})`,
+			Samples: []DateTimePatternSample{{"24.03.05 I 09:10:11", "2024/03/05 09:10"}}},
+		{Description: "YY.MM.DDHH:MM", Priority: 16, Groups: withMinute,
+			Regex:   `(\d{2})\.(\d{2})\.(\d{2})(\d{2}):(\d{2})`,
+			Samples: []DateTimePatternSample{{"24.03.0509:10", "2024/03/05 09:10"}}},
+		{Description: "YYYY-MM-DD HH:MM:SS (ISO 8601)", Priority: 17, Groups: full,
+			Regex:   `(\d{4})-(\d{2})-(\d{2})\s+(\d{2}):(\d{2}):(\d{2})`,
+			Samples: []DateTimePatternSample{{"2024-03-05 19:30:12", "2024/03/05 19:30"}}},
+		{Description: "YYYY-MM-DD (ISO 8601)", Priority: 18, Groups: dateOnly,
+			Regex:   `(\d{4})-(\d{2})-(\d{2})`,
+			Samples: []DateTimePatternSample{{"2024-03-05", "2024/03/05"}}},
+		{Description: "YY-MM-DD", Priority: 18, Groups: full,
+			Regex:   `^(\d{2})-(\d{2})-(\d{2})(?:\s+(\d{1,2}):(\d{2})(?::(\d{2}))?)?$`,
+			Samples: []DateTimePatternSample{{"24-03-05", "2024/03/05"}, {"24-03-05 19:30", "2024/03/05 19:30"}}},
+		{Description: "YYYY/MM/DD HH:MM:SS", Priority: 19, Groups: full,
+			Regex:   `(\d{4})/(\d{2})/(\d{2})\s+(\d{2}):(\d{2}):(\d{2})`,
+			Samples: []DateTimePatternSample{{"2024/03/05 19:30:12", "2024/03/05 19:30"}}},
+		// 표준 형식 (ParsedDateTime.Standard 결과를 다시 파싱하는 경우)
+		{Description: "YYYY/MM/DD HH:MM", Priority: 19, Groups: withMinute,
+			Regex:   `(\d{4})/(\d{2})/(\d{2})\s+(\d{1,2}):(\d{2})`,
+			Samples: []DateTimePatternSample{{"2024/03/05 19:30", "2024/03/05 19:30"}}},
+		{Description: "YYYY/MM/DD", Priority: 20, Groups: dateOnly,
+			Regex:   `(\d{4})/(\d{2})/(\d{2})`,
+			Samples: []DateTimePatternSample{{"2024/03/05", "2024/03/05"}}},
+		// 연도가 없는 표기 (청구 기간/업로드일 기준으로 연도 추론)
+		{Description: "M월 D일 오전/오후 H시 M분 (연도 없음)", Priority: 29, Groups: noYearMeridiem, Confidence: DATE_CONFIDENCE_NO_YEAR,
+			Regex:   `(?:^|\D)(\d{1,2})월\s*(\d{1,2})일` + weekdaySuffixPattern + `?\s*` + meridiemPattern + `?\s*(\d{1,2})시(?:\s*(\d{1,2})분)?`,
+			Samples: []DateTimePatternSample{{"3월 5일 오후 7시", "2024/03/05 19:00"}, {"3월 5일 (화) 오전 9시 10분", "2024/03/05 09:10"}}},
+		{Description: "MM/DD HH:MM (연도 없음)", Priority: 30, Groups: noYear, Confidence: DATE_CONFIDENCE_NO_YEAR,
+			Regex:   `(?:^|[^\d/])(\d{1,2})/(\d{1,2})\s+(\d{1,2}):(\d{2})(?::(\d{2}))?`,
+			Samples: []DateTimePatternSample{{"03/05 19:30", "2024/03/05 19:30"}, {"거래일시 12/31 23:10:05", "2024/12/31 23:10"}}},
+		{Description: "M월 D일 (연도 없음)", Priority: 31, Groups: noYear, Confidence: DATE_CONFIDENCE_NO_YEAR,
+			Regex:   `(?:^|\D)(\d{1,2})월\s*(\d{1,2})일(?:\s*(\d{1,2}):(\d{2})(?::(\d{2}))?)?`,
+			Samples: []DateTimePatternSample{{"3월 5일", "2024/03/05"}, {"3월 5일 19:30", "2024/03/05 19:30"}}},
+
+		// 시간만 있는 패턴 (오전/오후 표기 우선)
+		{Description: "오전/오후 HH:MM", Kind: DATETIME_PATTERN_KIND_TIME, Priority: 1,
+			Regex:   meridiemPattern + `\s*(\d{1,2}):(\d{1,2})`,
+			Groups:  DateTimePatternGroups{Meridiem: 1, Hour: 2, Minute: 3},
+			Samples: []DateTimePatternSample{{"오후 7:30", "19:30"}, {"오전 12:05", "00:05"}}},
+		{Description: "오전/오후 H시 M분", Kind: DATETIME_PATTERN_KIND_TIME, Priority: 2,
+			Regex:   meridiemPattern + `\s*(\d{1,2})시(?:\s*(\d{1,2})분)?`,
+			Groups:  DateTimePatternGroups{Meridiem: 1, Hour: 2, Minute: 3},
+			Samples: []DateTimePatternSample{{"오전 12시 5분", "00:05"}, {"오후 3시", "15:00"}}},
+		{Description: "HH:MM:SS", Kind: DATETIME_PATTERN_KIND_TIME, Priority: 3,
+			Regex:   `\b(\d{1,2}):(\d{1,2}):\d{1,2}\b`,
+			Groups:  DateTimePatternGroups{Hour: 1, Minute: 2},
+			Samples: []DateTimePatternSample{{"19:30:12", "19:30"}}},
+		{Description: "HH:MM", Kind: DATETIME_PATTERN_KIND_TIME, Priority: 4,
+			Regex:   `\b(\d{1,2}):(\d{1,2})\b`,
+			Groups:  DateTimePatternGroups{Hour: 1, Minute: 2},
+			Samples: []DateTimePatternSample{{"19:30", "19:30"}}},
+		{Description: "HH시MM분SS초", Kind: DATETIME_PATTERN_KIND_TIME, Priority: 5,
+			Regex:   `\b(\d{1,2})시\s*(\d{1,2})분\s*\d{1,2}초`,
+			Groups:  DateTimePatternGroups{Hour: 1, Minute: 2},
+			Samples: []DateTimePatternSample{{"19시 30분 12초", "19:30"}}},
+		{Description: "HH시MM분", Kind: DATETIME_PATTERN_KIND_TIME, Priority: 6,
+			Regex:   `\b(\d{1,2})시\s*(\d{1,2})분`,
+			Groups:  DateTimePatternGroups{Hour: 1, Minute: 2},
+			Samples: []DateTimePatternSample{{"19시 30분", "19:30"}}},
+		{Description: "HH시", Kind: DATETIME_PATTERN_KIND_TIME, Priority: 7,
+			Regex:   `\b(\d{1,2})시(?:[^\d분]|$)`,
+			Groups:  DateTimePatternGroups{Hour: 1},
+			Samples: []DateTimePatternSample{{"19시", "19:00"}}},
+	}
+}
+
+// 전역 패턴 목록 반환 (DATETIME_PATTERNS_FILE이 있으면 기본 패턴에 병합)
+func getDateTimePatternSet() (*DateTimePatternSet, error) {
+	dateTimePatternSetOnce.Do(func() {
+		dateTimePatternSet, dateTimePatternSetErr = loadDateTimePatternSet(getDateTimePatternsFile())
+	})
+	return dateTimePatternSet, dateTimePatternSetErr
+}
+
+// 현재 패턴 목록 (시작 시 검증되므로 로드 실패 시에는 기본 패턴 사용)
+func currentDateTimePatternSet() *DateTimePatternSet {
+	set, err := getDateTimePatternSet()
+	if err != nil {
+		set, _ = NewDateTimePatternSet(defaultDateTimePatternDefinitions())
+	}
+	return set
+}
+
+// 패턴 설정 파일 로드 (같은 description은 기본 패턴 대체, disabled면 제외)
+func loadDateTimePatternSet(path string) (*DateTimePatternSet, error) {
+	definitions := defaultDateTimePatternDefinitions()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("날짜 패턴 파일 읽기 실패: %v", err)
+		}
+		var file DateTimePatternFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("날짜 패턴 파일 파싱 실패 (%s): %v", path, err)
+		}
+		definitions = mergeDateTimePatternDefinitions(definitions, file.Patterns)
+	}
+
+	set, err := NewDateTimePatternSet(definitions)
+	if err != nil {
+		return nil, fmt.Errorf("날짜 패턴 검증 실패: %v", err)
+	}
+	set.source = path
+	if path != "" {
+		log.Printf("날짜 패턴 로드: 날짜 %d개, 시간 %d개 (%s)", len(set.dateTimes), len(set.times), path)
+	}
+	return set, nil
 }
 
-func GetDateTimePatterns() []DateTimePattern {
-	return []DateTimePattern{
-		// 🆕 새로운 패턴 추가 - YYYY.MM.DDHH:MM:SS (우선순위 높음)
-		{
-			Regex:       regexp.MustCompile(`(\d{4})\.(\d{2})\.(\d{2})(\d{2}):(\d{2}):(\d{2})`),
-			Description: "YYYY.MM.DDHH:MM:SS",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: 6,
-		},
-		// 🆕 새로운 패턴 추가 - YYYY.MM.DDHH:MM (분까지만)
-		{
-			Regex:       regexp.MustCompile(`(\d{4})\.(\d{2})\.(\d{2})(\d{2}):(\d{2})`),
-			Description: "YYYY.MM.DDHH:MM",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: -1,
-		},
-		// 기존 패턴들 (우선순위 순서대로)
-		{
-			Regex:       regexp.MustCompile(`(\d{4})\.\s*(\d{1,2})\.\s*(\d{1,2})\.\s*(\d{1,2}):\s*(\d{1,2}):\s*(\d{1,2})`),
-			Description: "YYYY. M. D. HH:MM:SS",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: 6,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{4})\.\s*(\d{1,2})\.\s*(\d{1,2})\.\s*(\d{1,2}):\s*(\d{1,2})`),
-			Description: "YYYY. M. D. HH:MM",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: -1,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{4})\.\s*(\d{1,2})\.\s*(\d{1,2})`),
-			Description: "YYYY. M. D",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   -1,
-			MinuteIndex: -1,
-			SecondIndex: -1,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{4})\.(\d{2})\.(\d{2})`),
-			Description: "YYYY.MM.DD",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   -1,
-			MinuteIndex: -1,
-			SecondIndex: -1,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{2})\.(\d{2})\.(\d{2})\s+(\d{1,2}):\s*(\d{1,2}):\s*(\d{1,2})`),
-			Description: "YY.MM.DD HH:MM:SS",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: 6,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{2})\.(\d{2})\.(\d{2})\s+[I|]\s+(\d{1,2}):\s*(\d{1,2}):\s*(\d{1,2})`),
-			Description: "YY.MM.DD I HH:MM:SS",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: 6,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{2})\.(\d{2})\.(\d{2})\s+(\d{1,2}):\s*(\d{1,2})`),
-			Description: "YY.MM.DD HH:MM",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: -1,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{2})\.(\d{2})\.(\d{2})(\d{2}):(\d{2})`),
-			Description: "YY.MM.DDHH:MM",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: -1,
-		},
-		{
-			Regex:       regexp.MustCompile(`^(\d{2})\.(\d{2})\.(\d{2})$`),
-			Description: "YY.MM.DD",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   -1,
-			MinuteIndex: -1,
-			SecondIndex: -1,
-		},
-		// 추가 패턴들 - 확장 가능
-		{
-			Regex:       regexp.MustCompile(`(\d{4})-(\d{2})-(\d{2})\s+(\d{2}):(\d{2}):(\d{2})`),
-			Description: "YYYY-MM-DD HH:MM:SS (ISO 8601)",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: 6,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{4})-(\d{2})-(\d{2})`),
-			Description: "YYYY-MM-DD (ISO 8601)",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   -1,
-			MinuteIndex: -1,
-			SecondIndex: -1,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{4})/(\d{2})/(\d{2})\s+(\d{2}):(\d{2}):(\d{2})`),
-			Description: "YYYY/MM/DD HH:MM:SS",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   4,
-			MinuteIndex: 5,
-			SecondIndex: 6,
-		},
-		{
-			Regex:       regexp.MustCompile(`(\d{4})/(\d{2})/(\d{2})`),
-			Description: "YYYY/MM/DD",
-			YearIndex:   1,
-			MonthIndex:  2,
-			DayIndex:    3,
-			HourIndex:   -1,
-			MinuteIndex: -1,
-			SecondIndex: -1,
-		},
+// 기본 패턴에 설정 파일 패턴 병합
+func mergeDateTimePatternDefinitions(base, overrides []DateTimePatternDefinition) []DateTimePatternDefinition {
+	merged := append([]DateTimePatternDefinition(nil), base...)
+	for _, override := range overrides {
+		replaced := false
+		for i := range merged {
+			if merged[i].Description == override.Description {
+				merged[i] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, override)
+		}
+	}
+
+	kept := merged[:0]
+	for _, definition := range merged {
+		if !definition.Disabled {
+			kept = append(kept, definition)
+		}
 	}
+	return kept
 }
 
-// GetSimpleTimePatterns 시간만 있는 간단한 패턴들을 반환
-func GetSimpleTimePatterns() []SimpleTimePattern {
-	return []SimpleTimePattern{
-		{
-			Regex:       regexp.MustCompile(`\b(\d{1,2}):\d{1,2}:\d{1,2}\b`),
-			Description: "HH:MM:SS",
-			HourIndex:   1,
-		},
-		{
-			Regex:       regexp.MustCompile(`\b(\d{1,2}):\d{1,2}\b`),
-			Description: "HH:MM",
-			HourIndex:   1,
-		},
-		{
-			Regex:       regexp.MustCompile(`\b(\d{1,2})시\s*\d{1,2}분\s*\d{1,2}초`),
-			Description: "HH시MM분SS초",
-			HourIndex:   1,
-		},
-		{
-			Regex:       regexp.MustCompile(`\b(\d{1,2})시\s*\d{1,2}분`),
-			Description: "HH시MM분",
-			HourIndex:   1,
-		},
-		{
-			Regex:       regexp.MustCompile(`\b(\d{1,2})시\b`),
-			Description: "HH시",
-			HourIndex:   1,
-		},
-		{
-			Regex:       regexp.MustCompile(`오전\s*(\d{1,2}):\d{1,2}`),
-			Description: "오전 HH:MM",
-			HourIndex:   1,
-		},
-		{
-			Regex:       regexp.MustCompile(`오후\s*(\d{1,2}):\d{1,2}`),
-			Description: "오후 HH:MM (12시간 형식)",
-			HourIndex:   1, // 주의: 오후 시간은 +12 처리 필요
-		},
+// 패턴 목록 생성자 (정규식, 그룹 인덱스, 샘플 검증 후 우선순위 정렬)
+func NewDateTimePatternSet(definitions []DateTimePatternDefinition) (*DateTimePatternSet, error) {
+	set := &DateTimePatternSet{definitions: append([]DateTimePatternDefinition(nil), definitions...)}
+	seen := make(map[string]bool)
+
+	for i, definition := range definitions {
+		if definition.Description == "" {
+			return nil, fmt.Errorf("패턴 %d: description이 필요합니다", i+1)
+		}
+		if seen[definition.Description] {
+			return nil, fmt.Errorf("패턴 %q: description이 중복되었습니다", definition.Description)
+		}
+		seen[definition.Description] = true
+
+		regex, err := regexp.Compile(definition.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("패턴 %q: 잘못된 정규식: %v", definition.Description, err)
+		}
+		groups := definition.Groups
+		for name, index := range map[string]int{
+			"year": groups.Year, "month": groups.Month, "day": groups.Day, "hour": groups.Hour,
+			"minute": groups.Minute, "second": groups.Second, "meridiem": groups.Meridiem,
+		} {
+			if index < 0 || index > regex.NumSubexp() {
+				return nil, fmt.Errorf("패턴 %q: %s 그룹 인덱스 %d가 범위를 벗어났습니다 (그룹 %d개)", definition.Description, name, index, regex.NumSubexp())
+			}
+		}
+		if len(definition.Samples) == 0 {
+			return nil, fmt.Errorf("패턴 %q: 검증용 샘플이 필요합니다", definition.Description)
+		}
+
+		switch definition.Kind {
+		case "", DATETIME_PATTERN_KIND_DATETIME:
+			if groups.Month == 0 || groups.Day == 0 {
+				return nil, fmt.Errorf("패턴 %q: month, day 그룹이 필요합니다 (year가 없으면 연도 추론)", definition.Description)
+			}
+			pattern := DateTimePattern{
+				Regex: regex, Description: definition.Description, Priority: definition.Priority,
+				YearIndex: groups.Year, MonthIndex: groups.Month, DayIndex: groups.Day,
+				HourIndex: groups.Hour, MinuteIndex: groups.Minute, SecondIndex: groups.Second,
+				MeridiemIndex: groups.Meridiem, Confidence: definition.Confidence,
+			}
+			for _, sample := range definition.Samples {
+				parsed := pattern.parse(sample.Input, dateTimePatternSampleContext)
+				if parsed == nil || parsed.Standard() != sample.Expect {
+					return nil, fmt.Errorf("패턴 %q: 샘플 %q 결과 %s (기대값 %s)", definition.Description, sample.Input, describeParsed(parsed, parsed.Standard), sample.Expect)
+				}
+			}
+			set.dateTimes = append(set.dateTimes, pattern)
+		case DATETIME_PATTERN_KIND_TIME:
+			if groups.Hour == 0 {
+				return nil, fmt.Errorf("패턴 %q: hour 그룹이 필요합니다", definition.Description)
+			}
+			pattern := SimpleTimePattern{
+				Regex: regex, Description: definition.Description, Priority: definition.Priority,
+				HourIndex: groups.Hour, MinuteIndex: groups.Minute, MeridiemIndex: groups.Meridiem,
+			}
+			for _, sample := range definition.Samples {
+				parsed := pattern.parse(sample.Input, dateTimePatternSampleContext)
+				if parsed == nil || parsed.Time.Format("15:04") != sample.Expect {
+					return nil, fmt.Errorf("패턴 %q: 샘플 %q 결과 %s (기대값 %s)", definition.Description, sample.Input, describeParsed(parsed, func() string { return parsed.Time.Format("15:04") }), sample.Expect)
+				}
+			}
+			set.times = append(set.times, pattern)
+		default:
+			return nil, fmt.Errorf("패턴 %q: 알 수 없는 kind %q (datetime | time)", definition.Description, definition.Kind)
+		}
+	}
+
+	// 우선순위에 따라 정렬 (같으면 정의 순서)
+	sort.SliceStable(set.dateTimes, func(i, j int) bool { return set.dateTimes[i].Priority < set.dateTimes[j].Priority })
+	sort.SliceStable(set.times, func(i, j int) bool { return set.times[i].Priority < set.times[j].Priority })
+
+	// 우선순위가 더 높은 다른 패턴이 샘플을 가로채면 경고만 남김
+	for _, definition := range set.definitions {
+		for _, sample := range definition.Samples {
+			if parsed, err := set.Parse(sample.Input, dateTimePatternSampleContext); err == nil && parsed.Pattern != definition.Description {
+				log.Printf("⚠️ 날짜 패턴 %q의 샘플 %q은 우선순위가 높은 %q 패턴으로 처리됩니다", definition.Description, sample.Input, parsed.Pattern)
+			}
+		}
 	}
+	return set, nil
 }
 
-// GetExistingDatePatterns YYYYMMDD 형식이 이미 있는 경우를 확인하는 패턴
-func GetExistingDatePatterns() []*regexp.Regexp {
-	return []*regexp.Regexp{
-		regexp.MustCompile(`(\d{8})`),               // YYYYMMDD
-		regexp.MustCompile(`(\d{4})(\d{2})(\d{2})`), // YYYYMMDD (그룹으로)
+// 검증 오류 메시지용 결과 설명
+func describeParsed(parsed *ParsedDateTime, format func() string) string {
+	if parsed == nil {
+		return "(일치하지 않음)"
 	}
+	return format()
 }
 
-// PatternPriority 패턴 우선순위 정의 (자주 사용되는 패턴을 앞에)
-var PatternPriority = map[string]int{
-	"YYYY.MM.DDHH:MM:SS":   1, // 🆕 새로운 패턴 최우선
-	"YYYY.MM.DDHH:MM":      2, // 🆕 새로운 패턴 (분까지)
-	"YY.MM.DD HH:MM:SS":    3, // 기존 가장 일반적
-	"YY.MM.DD HH:MM":       4,
-	"YY.MM.DD":             5,
-	"YYYY. M. D. HH:MM:SS": 6,
-	"YYYY. M. D. HH:MM":    7,
-	"YYYY. M. D":           8,
-	"YYYY.MM.DD":           9,
-	"YY.MM.DD I HH:MM:SS":  10,
-	"YY.MM.DDHH:MM":        11,
-	"YYYY-MM-DD HH:MM:SS":  12, // ISO 8601
-	"YYYY-MM-DD":           13,
-	"YYYY/MM/DD HH:MM:SS":  14,
-	"YYYY/MM/DD":           15,
+// 패턴 정의 목록 (설정 순서)
+func (s *DateTimePatternSet) Definitions() []DateTimePatternDefinition {
+	return append([]DateTimePatternDefinition(nil), s.definitions...)
 }
 
 // ValidateDateTime 날짜/시간 유효성 검증 함수
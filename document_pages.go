@@ -0,0 +1,730 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 문서 1개당 최대 페이지 수 (페이지 트리/IFD 순환 방지)
+const MAX_DOCUMENT_PAGES = 200
+
+// PDF 구조 제한 (조작된 파일 방어)
+const (
+	PDF_MAX_OBJECT_NUMBER      = 8_388_607        // PDF 명세의 최대 객체 번호
+	PDF_MAX_OBJECT_STREAM_SIZE = 64 * 1024 * 1024 // 객체 스트림 압축 해제 최대 크기
+)
+
+// DocumentPage 다중 페이지 문서에서 분리한 페이지 (단일 페이지 문서는 분리하지 않음)
+type DocumentPage struct {
+	Data   []byte
+	Number int // 1부터 시작
+}
+
+// PDF 구문 패턴
+var (
+	pdfObjectPattern     = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+	pdfRefPattern        = regexp.MustCompile(`(\d+)\s+(\d+)\s+R\b`)
+	pdfLeadingRefPattern = regexp.MustCompile(`^\d+\s+\d+\s+R\b`)
+	pdfRootPattern       = regexp.MustCompile(`/Root\s+(\d+)\s+(\d+)\s+R\b`)
+	pdfPagesRefPattern   = regexp.MustCompile(`/Pages\s+(\d+)\s+(\d+)\s+R\b`)
+	pdfNodeTypePattern   = regexp.MustCompile(`/Type\s*/(Pages|Page)\b`)
+	pdfKidsPattern       = regexp.MustCompile(`/Kids\s*\[([^\]]*)\]`)
+	pdfStartXrefPattern  = regexp.MustCompile(`startxref\s+(\d+)`)
+	pdfSkipTypePattern   = regexp.MustCompile(`/Type\s*/(Pages|Page|Catalog|XRef|ObjStm)\b`)
+	pdfIntPattern        = regexp.MustCompile(`/(N|First)\s+(\d+)\b`)
+	pdfLengthPattern     = regexp.MustCompile(`/Length\s+(\d+)(\s+\d+\s+R)?`)
+)
+
+// pdfRef 간접 참조 (객체 번호, 세대 번호)
+type pdfRef struct {
+	Num int
+	Gen int
+}
+
+// pdfDocument 페이지 분리에 필요한 최소한의 PDF 구조
+type pdfDocument struct {
+	data    []byte
+	objects map[int][]byte // 객체 번호 → obj ~ endobj 사이 본문
+	root    pdfRef         // 문서 카탈로그
+	pages   pdfRef         // 루트 페이지 트리
+}
+
+// 업로드 파일을 페이지 단위로 분리 (단일 페이지이거나 분리할 수 없으면 nil)
+func splitDocumentPages(imageFile ImageFile) []DocumentPage {
+	var pages []DocumentPage
+	var err error
+	switch imageFile.Format {
+	case IMAGE_FORMAT_PDF:
+		pages, err = splitPDFPages(imageFile.Data)
+	case IMAGE_FORMAT_TIFF:
+		pages, err = splitTIFFPages(imageFile.Data)
+	default:
+		return nil
+	}
+	if err != nil {
+		log.Printf("⚠️ 파일 '%s' 페이지 분리 실패, 문서 전체를 한 장으로 처리: %v", imageFile.Filename, err)
+		return nil
+	}
+	if len(pages) > 1 {
+		log.Printf("📄 파일 '%s': %d페이지로 분리", imageFile.Filename, len(pages))
+	}
+	return pages
+}
+
+// 페이지 파일명 (예: receipts.pdf 2페이지 → receipts_p2.pdf)
+func documentPageFilename(filename string, page int) string {
+	ext := filepath.Ext(filename)
+	return fmt.Sprintf("%s_p%d%s", strings.TrimSuffix(filename, ext), page, ext)
+}
+
+// 다중 페이지 TIFF 분리
+// 페이지(IFD)마다 해당 IFD의 태그와 이미지 데이터(스트립/타일)만 복사한 새 TIFF를 만듭니다.
+func splitTIFFPages(data []byte) ([]DocumentPage, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("TIFF 헤더가 너무 짧습니다")
+	}
+	var order binary.ByteOrder
+	switch string(data[:4]) {
+	case "II*\x00":
+		order = binary.LittleEndian
+	case "MM\x00*":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("지원하지 않는 TIFF 헤더입니다 (BigTIFF 미지원)")
+	}
+
+	// IFD 연결 목록 순회
+	var ifdOffsets []int
+	seen := make(map[int]bool)
+	for offset := int(order.Uint32(data[4:8])); offset != 0; {
+		if seen[offset] || len(ifdOffsets) >= MAX_DOCUMENT_PAGES {
+			return nil, fmt.Errorf("IFD 연결이 순환하거나 페이지가 너무 많습니다")
+		}
+		if offset < 8 || offset+2 > len(data) {
+			return nil, fmt.Errorf("IFD 위치(%d)가 파일 범위를 벗어났습니다", offset)
+		}
+		nextPointer := offset + 2 + 12*int(order.Uint16(data[offset:]))
+		if nextPointer+4 > len(data) {
+			return nil, fmt.Errorf("IFD 항목이 파일 범위를 벗어났습니다")
+		}
+		seen[offset] = true
+		ifdOffsets = append(ifdOffsets, offset)
+		offset = int(order.Uint32(data[nextPointer:]))
+	}
+	if len(ifdOffsets) <= 1 {
+		return nil, nil
+	}
+
+	pages := make([]DocumentPage, 0, len(ifdOffsets))
+	for i, offset := range ifdOffsets {
+		page, err := extractTIFFPage(data, order, offset)
+		if err != nil {
+			return nil, fmt.Errorf("%d페이지: %v", i+1, err)
+		}
+		pages = append(pages, DocumentPage{Data: page, Number: i + 1})
+	}
+	return pages, nil
+}
+
+// TIFF 필드 타입별 값 크기 (BYTE, ASCII, SHORT, LONG, RATIONAL, ..., IFD)
+var tiffTypeSizes = map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8, 13: 4}
+
+// TIFF 태그 번호
+const (
+	TIFF_TAG_STRIP_OFFSETS     = 273
+	TIFF_TAG_STRIP_BYTE_COUNTS = 279
+	TIFF_TAG_TILE_OFFSETS      = 324
+	TIFF_TAG_TILE_BYTE_COUNTS  = 325
+	TIFF_TAG_OLD_JPEG_OFFSET   = 513
+)
+
+// 다른 IFD를 가리키는 태그 (새 파일에서는 위치가 맞지 않으므로 제외)
+var tiffPointerTags = map[uint16]bool{330: true, 34665: true, 34853: true, 40965: true}
+
+// tiffEntry IFD 항목 (값은 원본 바이트 순서 그대로)
+type tiffEntry struct {
+	Tag   uint16
+	Type  uint16
+	Count int
+	Value []byte
+}
+
+// IFD 하나로 단일 페이지 TIFF 생성
+// 구성: 헤더 → IFD → IFD 밖 값 → 이미지 데이터 (스트립/타일 위치는 LONG으로 다시 기록)
+func extractTIFFPage(data []byte, order binary.ByteOrder, ifdOffset int) ([]byte, error) {
+	var entries []tiffEntry
+	var offsetsIndex = -1
+	var segments [][]byte
+	count := int(order.Uint16(data[ifdOffset:]))
+	for i := 0; i < count; i++ {
+		raw := data[ifdOffset+2+12*i : ifdOffset+14+12*i]
+		entry := tiffEntry{Tag: order.Uint16(raw), Type: order.Uint16(raw[2:])}
+		if entry.Tag == TIFF_TAG_OLD_JPEG_OFFSET {
+			return nil, fmt.Errorf("구형 JPEG 압축 TIFF는 분리할 수 없습니다")
+		}
+		size, known := tiffTypeSizes[entry.Type]
+		if !known || tiffPointerTags[entry.Tag] {
+			continue
+		}
+		entry.Count = int(order.Uint32(raw[4:]))
+		length := int64(entry.Count) * int64(size)
+		if length <= 4 {
+			entry.Value = raw[8 : 8+length]
+		} else {
+			start := int64(order.Uint32(raw[8:]))
+			if start+length > int64(len(data)) {
+				return nil, fmt.Errorf("태그 %d 값이 파일 범위를 벗어났습니다", entry.Tag)
+			}
+			entry.Value = data[start : start+length]
+		}
+		entries = append(entries, entry)
+	}
+
+	// 이미지 데이터 위치/크기 (스트립 또는 타일)
+	offsetTags := [][2]uint16{{TIFF_TAG_STRIP_OFFSETS, TIFF_TAG_STRIP_BYTE_COUNTS}, {TIFF_TAG_TILE_OFFSETS, TIFF_TAG_TILE_BYTE_COUNTS}}
+	for _, tags := range offsetTags {
+		offsets, offsetsAt := tiffEntryValues(entries, tags[0], order)
+		counts, _ := tiffEntryValues(entries, tags[1], order)
+		if offsetsAt < 0 {
+			continue
+		}
+		if offsetsIndex >= 0 || len(offsets) == 0 || len(offsets) != len(counts) {
+			return nil, fmt.Errorf("이미지 데이터 위치(태그 %d)와 크기(태그 %d)가 맞지 않습니다", tags[0], tags[1])
+		}
+		for i := range offsets {
+			if offsets[i]+counts[i] > int64(len(data)) {
+				return nil, fmt.Errorf("이미지 데이터가 파일 범위를 벗어났습니다")
+			}
+			segments = append(segments, data[offsets[i]:offsets[i]+counts[i]])
+		}
+		offsetsIndex = offsetsAt
+		entries[offsetsAt].Type = 4
+		entries[offsetsAt].Count = len(offsets)
+		entries[offsetsAt].Value = make([]byte, 4*len(offsets))
+	}
+	if offsetsIndex < 0 {
+		return nil, fmt.Errorf("이미지 데이터 위치 태그가 없습니다")
+	}
+
+	// IFD 밖 값 배치 (워드 경계 정렬)
+	valueStart := 8 + 2 + 12*len(entries) + 4
+	valueOffsets := make([]int, len(entries))
+	position := valueStart
+	for i, entry := range entries {
+		if len(entry.Value) > 4 {
+			valueOffsets[i] = position
+			position += len(entry.Value) + len(entry.Value)%2
+		}
+	}
+	for i, offset := 0, position; i < len(segments); i++ {
+		order.PutUint32(entries[offsetsIndex].Value[4*i:], uint32(offset))
+		offset += len(segments[i])
+	}
+
+	page := make([]byte, valueStart, position)
+	copy(page, data[:4])
+	order.PutUint32(page[4:], 8)
+	order.PutUint16(page[8:], uint16(len(entries)))
+	for i, entry := range entries {
+		raw := page[10+12*i : 22+12*i]
+		order.PutUint16(raw, entry.Tag)
+		order.PutUint16(raw[2:], entry.Type)
+		order.PutUint32(raw[4:], uint32(entry.Count))
+		if len(entry.Value) > 4 {
+			order.PutUint32(raw[8:], uint32(valueOffsets[i]))
+			page = append(page, entry.Value...)
+			if len(entry.Value)%2 == 1 {
+				page = append(page, 0)
+			}
+		} else {
+			copy(raw[8:], entry.Value)
+		}
+	}
+	for _, segment := range segments {
+		page = append(page, segment...)
+	}
+	return page, nil
+}
+
+// SHORT/LONG 배열 태그 값 (태그가 없으면 위치 -1)
+func tiffEntryValues(entries []tiffEntry, tag uint16, order binary.ByteOrder) ([]int64, int) {
+	for i, entry := range entries {
+		if entry.Tag != tag {
+			continue
+		}
+		values := make([]int64, entry.Count)
+		for j := range values {
+			switch entry.Type {
+			case 3:
+				values[j] = int64(order.Uint16(entry.Value[2*j:]))
+			case 4:
+				values[j] = int64(order.Uint32(entry.Value[4*j:]))
+			default:
+				return nil, i
+			}
+		}
+		return values, i
+	}
+	return nil, -1
+}
+
+// 다중 페이지 PDF 분리
+// 페이지마다 해당 페이지에서 참조하는 객체(내용 스트림, 글꼴, 이미지 등)만 모아 새 PDF를 만듭니다.
+// 암호화된 PDF는 분리하지 않습니다.
+func splitPDFPages(data []byte) ([]DocumentPage, error) {
+	doc, err := parsePDFDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var pageRefs []pdfRef
+	if err := doc.collectPages(doc.pages, 0, &pageRefs); err != nil {
+		return nil, err
+	}
+	if len(pageRefs) <= 1 {
+		return nil, nil
+	}
+
+	pages := make([]DocumentPage, 0, len(pageRefs))
+	for i, pageRef := range pageRefs {
+		page, err := doc.extractPage(pageRef)
+		if err != nil {
+			return nil, fmt.Errorf("%d페이지: %v", i+1, err)
+		}
+		pages = append(pages, DocumentPage{Data: page, Number: i + 1})
+	}
+	return pages, nil
+}
+
+// PDF 객체 색인 (직접 객체와 압축 객체 스트림)
+func parsePDFDocument(data []byte) (*pdfDocument, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return nil, fmt.Errorf("PDF 헤더가 없습니다")
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return nil, fmt.Errorf("암호화된 PDF는 분리할 수 없습니다")
+	}
+
+	doc := &pdfDocument{data: data, objects: make(map[int][]byte)}
+
+	// 직접 객체 (증분 갱신으로 다시 정의된 객체는 뒤의 정의 사용)
+	var objectStreams [][]byte
+	for _, match := range pdfObjectPattern.FindAllSubmatchIndex(data, -1) {
+		if match[0] > 0 && !isPDFWhitespace(data[match[0]-1]) {
+			continue
+		}
+		end := bytes.Index(data[match[1]:], []byte("endobj"))
+		if end < 0 {
+			continue
+		}
+		num, _ := strconv.Atoi(string(data[match[2]:match[3]]))
+		if num > PDF_MAX_OBJECT_NUMBER {
+			continue
+		}
+		body := data[match[1] : match[1]+end]
+		doc.objects[num] = body
+		if bytes.Contains(body, []byte("/ObjStm")) {
+			objectStreams = append(objectStreams, body)
+		}
+	}
+
+	// 객체 스트림에 압축된 객체 (직접 객체로 정의되지 않은 번호만)
+	for _, body := range objectStreams {
+		doc.indexObjectStream(body)
+	}
+
+	rootMatches := pdfRootPattern.FindAllSubmatch(data, -1)
+	if len(rootMatches) == 0 {
+		return nil, fmt.Errorf("문서 카탈로그(/Root)를 찾을 수 없습니다")
+	}
+	doc.root = parsePDFRef(rootMatches[len(rootMatches)-1])
+	catalog, ok := doc.objects[doc.root.Num]
+	if !ok {
+		return nil, fmt.Errorf("문서 카탈로그 객체 %d를 찾을 수 없습니다", doc.root.Num)
+	}
+	pagesMatch := pdfPagesRefPattern.FindSubmatch(catalog)
+	if pagesMatch == nil {
+		return nil, fmt.Errorf("페이지 트리(/Pages)를 찾을 수 없습니다")
+	}
+	doc.pages = parsePDFRef(pagesMatch)
+	if _, ok := doc.objects[doc.pages.Num]; !ok {
+		return nil, fmt.Errorf("페이지 트리 객체 %d를 찾을 수 없습니다", doc.pages.Num)
+	}
+
+	xrefMatches := pdfStartXrefPattern.FindAllSubmatch(data, -1)
+	if len(xrefMatches) == 0 {
+		return nil, fmt.Errorf("startxref를 찾을 수 없습니다")
+	}
+	startXref, err := strconv.Atoi(string(xrefMatches[len(xrefMatches)-1][1]))
+	if err != nil || startXref <= 0 || startXref >= len(data) {
+		return nil, fmt.Errorf("startxref 위치가 파일 범위를 벗어났습니다")
+	}
+	return doc, nil
+}
+
+// 객체 스트림(/Type /ObjStm) 색인 (FlateDecode만 지원, 실패하면 무시)
+func (d *pdfDocument) indexObjectStream(body []byte) {
+	dict, stream, ok := splitPDFStream(body)
+	if !ok {
+		return
+	}
+	if bytes.Contains(dict, []byte("/Filter")) {
+		if !bytes.Contains(dict, []byte("/FlateDecode")) || bytes.Contains(dict, []byte("/DecodeParms")) {
+			return
+		}
+		reader, err := zlib.NewReader(bytes.NewReader(stream))
+		if err != nil {
+			return
+		}
+		defer reader.Close()
+		if stream, err = io.ReadAll(io.LimitReader(reader, PDF_MAX_OBJECT_STREAM_SIZE+1)); err != nil || len(stream) > PDF_MAX_OBJECT_STREAM_SIZE {
+			return
+		}
+	}
+
+	values := make(map[string]int)
+	for _, match := range pdfIntPattern.FindAllSubmatch(dict, -1) {
+		values[string(match[1])], _ = strconv.Atoi(string(match[2]))
+	}
+	count, first := values["N"], values["First"]
+	if count <= 0 || first <= 0 || first > len(stream) {
+		return
+	}
+
+	header := strings.Fields(string(stream[:first]))
+	if len(header) < 2*count {
+		return
+	}
+	// 헤더의 오프셋은 파일 내용 그대로이므로 모든 항목의 범위를 확인
+	for i := 0; i < count; i++ {
+		num, errNum := strconv.Atoi(header[2*i])
+		start, errStart := strconv.Atoi(header[2*i+1])
+		end, errEnd := len(stream)-first, error(nil)
+		if i+1 < count {
+			end, errEnd = strconv.Atoi(header[2*i+3])
+		}
+		if errNum != nil || errStart != nil || errEnd != nil || num < 0 || num > PDF_MAX_OBJECT_NUMBER || start < 0 || start > end || first+end > len(stream) {
+			return
+		}
+		if _, exists := d.objects[num]; !exists {
+			d.objects[num] = stream[first+start : first+end]
+		}
+	}
+}
+
+// 페이지 트리 순회 (문서 순서대로 페이지 참조 수집)
+func (d *pdfDocument) collectPages(ref pdfRef, depth int, pages *[]pdfRef) error {
+	if depth > 32 || len(*pages) > MAX_DOCUMENT_PAGES {
+		return fmt.Errorf("페이지 트리가 너무 깊거나 페이지가 너무 많습니다")
+	}
+	body, ok := d.objects[ref.Num]
+	if !ok {
+		return fmt.Errorf("페이지 객체 %d를 찾을 수 없습니다", ref.Num)
+	}
+
+	nodeType := pdfNodeTypePattern.FindSubmatch(body)
+	if nodeType == nil {
+		return fmt.Errorf("객체 %d는 페이지 트리 노드가 아닙니다", ref.Num)
+	}
+	if string(nodeType[1]) == "Page" {
+		*pages = append(*pages, ref)
+		return nil
+	}
+
+	kids := pdfKidsPattern.FindSubmatch(body)
+	if kids == nil {
+		return fmt.Errorf("페이지 트리 노드 %d에 /Kids 배열이 없습니다", ref.Num)
+	}
+	for _, kid := range pdfRefPattern.FindAllSubmatch(kids[1], -1) {
+		if err := d.collectPages(parsePDFRef(kid), depth+1, pages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 페이지에서 상속받는 속성 (상위 페이지 트리 노드에 정의될 수 있음)
+var pdfInheritedKeys = []string{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+// 페이지 하나만 담은 새 PDF 생성
+// 페이지 사전에서 참조를 따라가며 필요한 객체만 모으고 1번부터 번호를 다시 매깁니다.
+// 다른 페이지나 페이지 트리를 가리키는 참조(주석, 링크 대상 등)는 null로 바꿉니다.
+func (d *pdfDocument) extractPage(page pdfRef) ([]byte, error) {
+	pageDict, err := d.pageDictionary(page)
+	if err != nil {
+		return nil, err
+	}
+
+	// 참조 객체 수집 (사전 부분만 탐색, 스트림 데이터는 그대로 복사)
+	order := []int{page.Num}
+	numbers := map[int]int{page.Num: 1}
+	bodies := map[int][]byte{page.Num: pageDict}
+	for i := 0; i < len(order); i++ {
+		dict, _ := splitPDFObject(bodies[order[i]])
+		for _, match := range pdfRefPattern.FindAllSubmatch(dict, -1) {
+			ref := parsePDFRef(match)
+			if _, seen := numbers[ref.Num]; seen {
+				continue
+			}
+			body, ok := d.objects[ref.Num]
+			if !ok {
+				continue
+			}
+			if objectDict, _ := splitPDFObject(body); pdfSkipTypePattern.Match(objectDict) {
+				continue
+			}
+			order = append(order, ref.Num)
+			numbers[ref.Num] = len(order)
+			bodies[ref.Num] = body
+		}
+	}
+	catalogNum, pagesNum := len(order)+1, len(order)+2
+
+	var buf bytes.Buffer
+	header, _, _ := bytes.Cut(d.data, []byte("\n"))
+	buf.Write(bytes.TrimRight(header, "\r"))
+	buf.WriteString("\n%\xE2\xE3\xCF\xD3\n")
+
+	offsets := make([]int, 0, pagesNum)
+	for _, num := range order {
+		dict, rest := splitPDFObject(bodies[num])
+		dict = pdfRefPattern.ReplaceAllFunc(dict, func(match []byte) []byte {
+			ref := parsePDFRef(pdfRefPattern.FindSubmatch(match))
+			if newNum, ok := numbers[ref.Num]; ok {
+				return []byte(fmt.Sprintf("%d 0 R", newNum))
+			}
+			return []byte("null")
+		})
+		if num == page.Num {
+			dict = append(bytes.TrimSuffix(dict, []byte(">>")), []byte(fmt.Sprintf(" /Parent %d 0 R >>", pagesNum))...)
+		}
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", numbers[num])
+		buf.Write(bytes.TrimSpace(append(append([]byte(nil), dict...), rest...)))
+		buf.WriteString("\nendobj\n")
+	}
+	offsets = append(offsets, buf.Len())
+	fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /Catalog /Pages %d 0 R >>\nendobj\n", catalogNum, pagesNum)
+	offsets = append(offsets, buf.Len())
+	fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /Pages /Kids [1 0 R] /Count 1 >>\nendobj\n", pagesNum)
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", pagesNum+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", pagesNum+1, catalogNum, xrefOffset)
+	return buf.Bytes(), nil
+}
+
+// 새 문서용 페이지 사전 (/Parent, 주석 제거, 상속 속성 복사, 끝의 >>는 /Parent 추가 위치)
+func (d *pdfDocument) pageDictionary(page pdfRef) ([]byte, error) {
+	dict := bytes.TrimSpace(d.objects[page.Num])
+	if !bytes.HasPrefix(dict, []byte("<<")) || !bytes.HasSuffix(dict, []byte(">>")) {
+		return nil, fmt.Errorf("페이지 객체 %d가 사전이 아닙니다", page.Num)
+	}
+
+	parent, _ := pdfDictValue(dict, "Parent")
+	for _, key := range []string{"Parent", "Annots", "B"} {
+		dict = pdfRemoveDictEntry(dict, key)
+	}
+
+	// 상위 노드에서 상속받는 속성 (가장 가까운 노드 기준)
+	var inherited []byte
+	for _, key := range pdfInheritedKeys {
+		if _, ok := pdfDictValue(dict, key); ok {
+			continue
+		}
+		node := parent
+		for depth := 0; depth < 32 && node != nil; depth++ {
+			match := pdfRefPattern.FindSubmatch(node)
+			if match == nil {
+				break
+			}
+			nodeDict := d.objects[parsePDFRef(match).Num]
+			if value, ok := pdfDictValue(nodeDict, key); ok {
+				inherited = append(inherited, fmt.Sprintf(" /%s %s", key, value)...)
+				break
+			}
+			node, _ = pdfDictValue(nodeDict, "Parent")
+		}
+	}
+
+	result := append([]byte(nil), bytes.TrimSuffix(dict, []byte(">>"))...)
+	result = append(result, inherited...)
+	return append(result, []byte(" >>")...), nil
+}
+
+// 객체 본문을 사전 부분과 stream ~ endstream 부분으로 분리 (참조 번호는 사전 부분에서만 바꿈)
+func splitPDFObject(body []byte) ([]byte, []byte) {
+	if start := bytes.Index(body, []byte("stream")); start >= 0 {
+		return body[:start], body[start:]
+	}
+	return body, nil
+}
+
+// 최상위 사전에서 키의 값 (참조, 배열, 사전 등 원문 그대로)
+func pdfDictValue(dict []byte, key string) ([]byte, bool) {
+	_, valueStart, valueEnd, ok := pdfFindDictEntry(dict, key)
+	if !ok {
+		return nil, false
+	}
+	return dict[valueStart:valueEnd], true
+}
+
+// 최상위 사전에서 키와 값 제거
+func pdfRemoveDictEntry(dict []byte, key string) []byte {
+	keyStart, _, valueEnd, ok := pdfFindDictEntry(dict, key)
+	if !ok {
+		return dict
+	}
+	return append(append([]byte(nil), dict[:keyStart]...), dict[valueEnd:]...)
+}
+
+// 최상위 사전(<< >>)의 키 위치와 값 범위 찾기
+func pdfFindDictEntry(dict []byte, key string) (int, int, int, bool) {
+	i := bytes.Index(dict, []byte("<<"))
+	if i < 0 {
+		return 0, 0, 0, false
+	}
+	for i += 2; i < len(dict); {
+		i = skipPDFWhitespace(dict, i)
+		if i >= len(dict) || dict[i] != '/' {
+			return 0, 0, 0, false
+		}
+		keyStart := i
+		i = skipPDFValue(dict, i)
+		name := string(dict[keyStart+1 : i])
+		valueStart := skipPDFWhitespace(dict, i)
+		valueEnd := skipPDFValue(dict, valueStart)
+		if valueEnd <= valueStart {
+			return 0, 0, 0, false
+		}
+		if name == key {
+			return keyStart, valueStart, valueEnd, true
+		}
+		i = valueEnd
+	}
+	return 0, 0, 0, false
+}
+
+// 값 하나 건너뛰기 (사전, 배열, 문자열, 이름, 참조, 숫자 등) 후 다음 위치 반환
+func skipPDFValue(b []byte, i int) int {
+	if i >= len(b) {
+		return i
+	}
+	switch {
+	case b[i] == '(':
+		for depth := 0; i < len(b); i++ {
+			switch b[i] {
+			case '\\':
+				i++
+			case '(':
+				depth++
+			case ')':
+				if depth--; depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return len(b)
+	case bytes.HasPrefix(b[i:], []byte("<<")), b[i] == '[':
+		for depth := 0; i < len(b); {
+			switch {
+			case bytes.HasPrefix(b[i:], []byte("<<")):
+				depth++
+				i += 2
+			case bytes.HasPrefix(b[i:], []byte(">>")), b[i] == ']':
+				if b[i] == ']' {
+					i++
+				} else {
+					i += 2
+				}
+				if depth--; depth == 0 {
+					return i
+				}
+			case b[i] == '[':
+				depth++
+				i++
+			case b[i] == '(' || b[i] == '<':
+				i = skipPDFValue(b, i)
+			default:
+				i++
+			}
+		}
+		return len(b)
+	case b[i] == '<':
+		if end := bytes.IndexByte(b[i:], '>'); end >= 0 {
+			return i + end + 1
+		}
+		return len(b)
+	}
+
+	// 이름, 숫자, 키워드 (숫자 뒤에 "세대 R"이 오면 참조로 처리)
+	start := i
+	for i++; i < len(b) && !isPDFWhitespace(b[i]) && !isPDFDelimiter(b[i]); i++ {
+	}
+	if b[start] >= '0' && b[start] <= '9' {
+		if match := pdfLeadingRefPattern.FindIndex(b[start:]); match != nil {
+			return start + match[1]
+		}
+	}
+	return i
+}
+
+func skipPDFWhitespace(b []byte, i int) int {
+	for i < len(b) && isPDFWhitespace(b[i]) {
+		i++
+	}
+	return i
+}
+
+func isPDFDelimiter(b byte) bool {
+	return bytes.IndexByte([]byte("()<>[]{}/%"), b) >= 0
+}
+
+// 객체 본문을 사전과 스트림 데이터로 분리 (스트림이 없으면 본문 전체가 사전)
+func splitPDFStream(body []byte) ([]byte, []byte, bool) {
+	start := bytes.Index(body, []byte("stream"))
+	if start < 0 {
+		return body, nil, false
+	}
+	dict := body[:start]
+	data := body[start+len("stream"):]
+	data = bytes.TrimPrefix(data, []byte("\r"))
+	data = bytes.TrimPrefix(data, []byte("\n"))
+
+	// /Length가 직접 값이면 사용, 간접 참조면 endstream 위치로 판단
+	if match := pdfLengthPattern.FindSubmatch(dict); match != nil && len(match[2]) == 0 {
+		if length, err := strconv.Atoi(string(match[1])); err == nil && length <= len(data) {
+			return dict, data[:length], true
+		}
+	}
+	end := bytes.LastIndex(data, []byte("endstream"))
+	if end < 0 {
+		return dict, nil, false
+	}
+	return dict, bytes.TrimRight(data[:end], "\r\n"), true
+}
+
+func parsePDFRef(match [][]byte) pdfRef {
+	num, _ := strconv.Atoi(string(match[1]))
+	gen, _ := strconv.Atoi(string(match[2]))
+	return pdfRef{Num: num, Gen: gen}
+}
+
+func isPDFWhitespace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\r', '\n', '\f', 0:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/tiff"
+)
+
+// 테스트용 PDF 작성 (objects[i]는 i+1번 객체 본문, 클래식 xref)
+func buildTestPDF(objects []string, root int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, body := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, root, xref)
+	return buf.Bytes()
+}
+
+func testContentStream(text string) string {
+	content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET\n%% %s", text, strings.Repeat("x", 4000))
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content)
+}
+
+// 3페이지 PDF (MediaBox/Resources는 루트 페이지 트리에서 상속)
+func buildMultiPagePDF() []byte {
+	return buildTestPDF([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R 5 0 R] /Count 3 /MediaBox [0 0 612 792] /Resources << /Font << /F1 9 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 6 0 R /Annots [10 0 R] >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 8 0 R /Rotate 90 >>",
+		testContentStream("Receipt One"),
+		testContentStream("Receipt Two"),
+		testContentStream("Receipt Three"),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Annot /Subtype /Link /Rect [0 0 10 10] /Dest [4 0 R /Fit] >>",
+	}, 1)
+}
+
+// xref 오프셋이 모두 해당 객체 시작을 가리키는지 확인
+func checkPDFXref(t *testing.T, data []byte) {
+	t.Helper()
+	xrefAt := bytes.LastIndex(data, []byte("startxref"))
+	fields := strings.Fields(string(data[xrefAt:]))
+	offset, err := strconv.Atoi(fields[1])
+	if err != nil || !bytes.HasPrefix(data[offset:], []byte("xref\n")) {
+		t.Fatalf("startxref(%s)가 xref를 가리키지 않습니다", fields[1])
+	}
+	lines := strings.Split(string(data[offset:]), "\n")
+	var count int
+	fmt.Sscanf(lines[1], "0 %d", &count)
+	for num := 1; num < count; num++ {
+		entry, _ := strconv.Atoi(strings.Fields(lines[2+num])[0])
+		if !bytes.HasPrefix(data[entry:], []byte(fmt.Sprintf("%d 0 obj", num))) {
+			t.Errorf("xref의 %d번 객체 위치(%d)가 잘못되었습니다", num, entry)
+		}
+	}
+}
+
+func TestSplitPDFPagesExtractsEachPage(t *testing.T) {
+	data := buildMultiPagePDF()
+	pages, err := splitPDFPages(data)
+	if err != nil {
+		t.Fatalf("분리 실패: %v", err)
+	}
+	if len(pages) != 3 {
+		t.Fatalf("페이지 수 = %d, want 3", len(pages))
+	}
+
+	texts := []string{"Receipt One", "Receipt Two", "Receipt Three"}
+	for i, page := range pages {
+		if page.Number != i+1 {
+			t.Errorf("페이지 번호 = %d, want %d", page.Number, i+1)
+		}
+		for j, text := range texts {
+			if contains := bytes.Contains(page.Data, []byte(text)); contains != (i == j) {
+				t.Errorf("%d페이지에 %q 포함 = %v", i+1, text, contains)
+			}
+		}
+		if len(page.Data) >= len(data)/2 {
+			t.Errorf("%d페이지 크기 %d가 원본(%d)과 비슷합니다", i+1, len(page.Data), len(data))
+		}
+		if bytes.Contains(page.Data, []byte("/Prev")) || bytes.Contains(page.Data, []byte("/Annot")) {
+			t.Errorf("%d페이지에 이전 xref나 주석이 남아 있습니다", i+1)
+		}
+		for _, inherited := range []string{"/MediaBox [0 0 612 792]", "/Font << /F1"} {
+			if !bytes.Contains(page.Data, []byte(inherited)) {
+				t.Errorf("%d페이지에 상속 속성 %q가 없습니다", i+1, inherited)
+			}
+		}
+		checkPDFXref(t, page.Data)
+
+		// 분리한 페이지는 단일 페이지 문서
+		doc, err := parsePDFDocument(page.Data)
+		if err != nil {
+			t.Fatalf("%d페이지 파싱 실패: %v", i+1, err)
+		}
+		var refs []pdfRef
+		if err := doc.collectPages(doc.pages, 0, &refs); err != nil || len(refs) != 1 {
+			t.Errorf("%d페이지 페이지 트리 = %v, %v", i+1, refs, err)
+		}
+	}
+	if !bytes.Contains(pages[2].Data, []byte("/Rotate 90")) {
+		t.Errorf("3페이지의 /Rotate가 없습니다")
+	}
+}
+
+// 페이지 객체가 압축 객체 스트림에 있고 xref 스트림을 쓰는 PDF
+func buildObjectStreamPDF() []byte {
+	compressed := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 300 400] >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 5 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+	}
+	var header, body strings.Builder
+	for i, object := range compressed {
+		fmt.Fprintf(&header, "%d %d ", i+1, body.Len())
+		body.WriteString(object + "\n")
+	}
+	var packed bytes.Buffer
+	writer := zlib.NewWriter(&packed)
+	writer.Write([]byte(header.String() + body.String()))
+	writer.Close()
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n")
+	for i, text := range []string{"Scan A", "Scan B"} {
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+5, testContentStream(text))
+	}
+	fmt.Fprintf(&buf, "7 0 obj\n<< /Type /ObjStm /N %d /First %d /Filter /FlateDecode /Length %d >>\nstream\n",
+		len(compressed), header.Len(), packed.Len())
+	buf.Write(packed.Bytes())
+	buf.WriteString("\nendstream\nendobj\n")
+	xref := buf.Len()
+	buf.WriteString("8 0 obj\n<< /Type /XRef /Size 9 /Root 1 0 R /W [1 4 2] /Length 0 >>\nstream\n\nendstream\nendobj\n")
+	fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", xref)
+	return buf.Bytes()
+}
+
+func TestSplitPDFPagesWithObjectStreams(t *testing.T) {
+	pages, err := splitPDFPages(buildObjectStreamPDF())
+	if err != nil {
+		t.Fatalf("분리 실패: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("페이지 수 = %d, want 2", len(pages))
+	}
+	for i, page := range pages {
+		if !bytes.Contains(page.Data, []byte([]string{"Scan A", "Scan B"}[i])) {
+			t.Errorf("%d페이지 내용이 없습니다", i+1)
+		}
+		for _, unwanted := range []string{"/XRef", "/ObjStm", "/Prev"} {
+			if bytes.Contains(page.Data, []byte(unwanted)) {
+				t.Errorf("%d페이지에 %s가 남아 있습니다", i+1, unwanted)
+			}
+		}
+		if !bytes.Contains(page.Data, []byte("/MediaBox [0 0 300 400]")) {
+			t.Errorf("%d페이지에 상속 MediaBox가 없습니다", i+1)
+		}
+		checkPDFXref(t, page.Data)
+	}
+}
+
+func TestSplitPDFPagesRejectsMalformed(t *testing.T) {
+	valid := buildMultiPagePDF()
+	objectStream := func(header string, n, first int) []byte {
+		return buildTestPDF([]string{
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 >>",
+			fmt.Sprintf("<< /Type /ObjStm /N %d /First %d /Length %d >>\nstream\n%s\nendstream", n, first, len(header), header),
+		}, 1)
+	}
+
+	tests := map[string][]byte{
+		"음수 오프셋":         objectStream("3 -39 4 0 << >>", 2, 9),
+		"역순 오프셋":         objectStream("3 5 4 0 << /Type /Page >>", 2, 9),
+		"범위 밖 오프셋":       objectStream("3 0 4 900 << /Type /Page >>", 2, 9),
+		"잘못된 First":      objectStream("3 0 4 1 << >>", 2, 9999),
+		"범위 밖 startxref": bytes.Replace(valid, []byte("startxref\n"), []byte("startxref\n9"), 1),
+		"없는 페이지 객체":      bytes.Replace(valid, []byte("[3 0 R 4 0 R 5 0 R]"), []byte("[3 0 R 40 0 R 5 0 R]"), 1),
+		"순환 페이지 트리":      bytes.Replace(valid, []byte("[3 0 R 4 0 R 5 0 R]"), []byte("[3 0 R 2 0 R 5 0 R]"), 1),
+		"잘린 파일":          valid[:len(valid)/3],
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			pages, err := splitPDFPages(data)
+			if err == nil && len(pages) > 1 {
+				t.Errorf("손상된 PDF가 %d페이지로 분리되었습니다", len(pages))
+			}
+		})
+	}
+}
+
+// 테스트용 다중 페이지 TIFF (8비트 흑백, 페이지마다 단색)
+func buildMultiPageTIFF(order binary.ByteOrder, shades []byte, width, height int) []byte {
+	var buf bytes.Buffer
+	if order == binary.LittleEndian {
+		buf.WriteString("II*\x00")
+	} else {
+		buf.WriteString("MM\x00*")
+	}
+	binary.Write(&buf, order, uint32(8))
+
+	const entryCount = 9
+	for i, shade := range shades {
+		ifdStart := buf.Len()
+		pixelsStart := ifdStart + 2 + 12*entryCount + 4
+		next := uint32(0)
+		if i+1 < len(shades) {
+			next = uint32(pixelsStart + width*height)
+		}
+		entries := [][3]uint32{
+			{256, 3, uint32(width)}, {257, 3, uint32(height)}, {258, 3, 8}, {259, 3, 1}, {262, 3, 1},
+			{273, 4, uint32(pixelsStart)}, {277, 3, 1}, {278, 3, uint32(height)}, {279, 4, uint32(width * height)},
+		}
+		binary.Write(&buf, order, uint16(entryCount))
+		for _, entry := range entries {
+			binary.Write(&buf, order, uint16(entry[0]))
+			binary.Write(&buf, order, uint16(entry[1]))
+			binary.Write(&buf, order, uint32(1))
+			if entry[1] == 3 {
+				binary.Write(&buf, order, uint16(entry[2]))
+				binary.Write(&buf, order, uint16(0))
+			} else {
+				binary.Write(&buf, order, entry[2])
+			}
+		}
+		binary.Write(&buf, order, next)
+		buf.Write(bytes.Repeat([]byte{shade}, width*height))
+	}
+	return buf.Bytes()
+}
+
+func TestSplitTIFFPagesExtractsEachPage(t *testing.T) {
+	shades := []byte{20, 120, 220}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		data := buildMultiPageTIFF(order, shades, 40, 30)
+		pages, err := splitTIFFPages(data)
+		if err != nil {
+			t.Fatalf("%v: 분리 실패: %v", order, err)
+		}
+		if len(pages) != len(shades) {
+			t.Fatalf("%v: 페이지 수 = %d, want %d", order, len(pages), len(shades))
+		}
+		for i, page := range pages {
+			if len(page.Data) >= len(data)/2 {
+				t.Errorf("%v: %d페이지 크기 %d가 원본(%d)과 비슷합니다", order, i+1, len(page.Data), len(data))
+			}
+			img, err := tiff.Decode(bytes.NewReader(page.Data))
+			if err != nil {
+				t.Fatalf("%v: %d페이지 디코딩 실패: %v", order, i+1, err)
+			}
+			if img.Bounds() != image.Rect(0, 0, 40, 30) {
+				t.Errorf("%v: %d페이지 크기 = %v", order, i+1, img.Bounds())
+			}
+			if gray, ok := img.(*image.Gray); !ok || gray.GrayAt(5, 5).Y != shades[i] {
+				t.Errorf("%v: %d페이지 픽셀 값이 다릅니다", order, i+1)
+			}
+		}
+	}
+}
+
+func TestSplitTIFFPagesRejectsMalformed(t *testing.T) {
+	valid := buildMultiPageTIFF(binary.LittleEndian, []byte{10, 200}, 16, 16)
+	secondIFD := 8 + 2 + 12*9 + 4 + 16*16
+
+	withUint32 := func(position int, value uint32) []byte {
+		data := append([]byte(nil), valid...)
+		binary.LittleEndian.PutUint32(data[position:], value)
+		return data
+	}
+	stripOffsetValue := secondIFD + 2 + 12*5 + 8
+
+	tests := map[string][]byte{
+		"순환 IFD":   withUint32(secondIFD+2+12*9, uint32(secondIFD)),
+		"범위 밖 IFD": withUint32(secondIFD+2+12*9, 1<<30),
+		"범위 밖 스트립": withUint32(stripOffsetValue, uint32(len(valid)-10)),
+		"잘린 파일":    valid[:secondIFD+20],
+		"너무 짧은 헤더": valid[:6],
+		"큰 값 개수":   withUint32(secondIFD+2+12*5+4, 1<<31),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if pages, err := splitTIFFPages(data); err == nil && len(pages) > 1 {
+				t.Errorf("손상된 TIFF가 %d페이지로 분리되었습니다", len(pages))
+			}
+		})
+	}
+}
@@ -3,9 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
-	"regexp"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -19,19 +17,13 @@ func NewExcelService() *ExcelService {
 	return &ExcelService{}
 }
 
-// 금액 텍스트 정리 (예: "32,300 원" -> "32300")
-func (e *ExcelService) cleanAmountText(amountText string) string {
-	re := regexp.MustCompile("[0-9,]+")
-	matches := re.FindAllString(amountText, -1)
-	if len(matches) > 0 {
-		return strings.ReplaceAll(matches[0], ",", "")
+// 날짜 형식 변환 (YYYYMMDD, 인식할 수 없으면 원문 유지)
+func (e *ExcelService) convertDateFormat(dateText string, dateContext DateTimeContext) string {
+	parsed, err := ParseDateTimeWithContext(dateText, dateContext)
+	if err != nil {
+		return dateText
 	}
-	return amountText
-}
-
-// 날짜 형식 변환 (공통 함수 사용)
-func (e *ExcelService) convertDateFormat(dateText string) string {
-	return convertDateToYYYYMMDD(dateText)
+	return parsed.YYYYMMDD()
 }
 
 // 결제일 계산 (현재 날짜 기준 10일 이전이면 당월 15일, 이후면 다음달 15일)
@@ -49,52 +41,33 @@ func (e *ExcelService) calculatePaymentDate() string {
 	return paymentDate.Format("20060102")
 }
 
-// 기본 RMK_DC 생성 (MM/DD_이름_카테고리 형식)
-func (e *ExcelService) generateDefaultRemark(issueDate, userName, category string) string {
+// 기본 RMK_DC 생성 (카테고리 비고 템플릿, 기본 MM/DD_이름_카테고리 형식)
+func (e *ExcelService) generateDefaultRemark(issueDate, userName, category string, dateContext DateTimeContext) string {
 	if userName == "" {
-		return fmt.Sprintf("카테고리: %s", e.getCategoryLabel(category))
+		return fmt.Sprintf("카테고리: %s", getCategoryLabel(category))
 	}
 
 	monthDay := "MM/DD"
-	convertedDate := convertDateToYYYYMMDD(issueDate)
-	if len(convertedDate) >= 8 {
-		month := convertedDate[4:6]
-		day := convertedDate[6:8]
-		monthDay = fmt.Sprintf("%s/%s", month, day)
+	if parsed, err := ParseDateTimeWithContext(issueDate, dateContext); err == nil {
+		monthDay = parsed.Time.Format("01/02")
 	}
 
-	categoryLabel := e.getCategoryLabel(category)
-	return fmt.Sprintf("%s_%s_%s", monthDay, userName, categoryLabel)
-}
-
-// 카테고리 코드를 라벨로 변환
-func (e *ExcelService) getCategoryLabel(category string) string {
-	categoryLabels := map[string]string{
-		"6110": "조식",
-		"6120": "중식",
-		"6130": "석식",
-		"6310": "교통정산",
-		"6320": "국내출장",
-	}
-	if label, exists := categoryLabels[category]; exists {
-		return label
-	}
-	return category
+	return currentCategoryCatalog().RenderRemark(category, RemarkValues{Date: monthDay, Names: userName})
 }
 
 // Excel 데이터 생성 (통합 함수)
 func (e *ExcelService) createExcelData(
-	category, remarks, trNM, supAM, attrCD, issDT, payDT,
-	bankCD, baNB, depositorDC, deptCD, empCD string) *ExcelData {
+	category, remarks, trNM string, supAM, vatAM Money, attrCD, issDT, payDT,
+	bankCD, baNB, depositorDC, deptCD, empCD string, dateContext DateTimeContext) *ExcelData {
 
 	return &ExcelData{
 		CASHCD:      category,
 		RMKDC:       remarks,
 		TRNM:        trNM,
-		SUPAM:       e.cleanAmountText(supAM),
-		VATAM:       "0",
+		SUPAM:       supAM,
+		VATAM:       vatAM,
 		ATTRCD:      attrCD,
-		ISSDT:       e.convertDateFormat(issDT),
+		ISSDT:       e.convertDateFormat(issDT, dateContext),
 		PAYDT:       payDT,
 		BANKCD:      bankCD,
 		BANB:        baNB,
@@ -105,13 +78,13 @@ func (e *ExcelService) createExcelData(
 }
 
 // 카테고리와 함께 비동기 OCR 결과를 Excel 데이터로 변환
-func (e *ExcelService) ConvertAsyncOCRToExcelDataWithCategory(ocrResults []*SingleImageOCRResultWithCategory, userName, depositorDC, deptCD, empCD, bankCD, baNB, attrCD string) ([]*ExcelData, error) {
+func (e *ExcelService) ConvertAsyncOCRToExcelDataWithCategory(ocrResults []*SingleImageOCRResultWithCategory, userName, depositorDC, deptCD, empCD, bankCD, baNB, attrCD string, dateContext DateTimeContext) ([]*ExcelData, error) {
 	if len(ocrResults) == 0 {
 		return nil, fmt.Errorf("OCR 결과가 없습니다")
 	}
 
 	var allExcelData []*ExcelData
-	ocrService := NewOCRService()
+	ocrService := currentOCRService()
 	payDT := e.calculatePaymentDate()
 
 	log.Printf("=== Excel 데이터 변환 시작 (카테고리 포함) ===")
@@ -129,8 +102,10 @@ func (e *ExcelService) ConvertAsyncOCRToExcelDataWithCategory(ocrResults []*Sing
 		// 필드에서 값 추출
 		trNM := ocrService.ExtractFieldValue(image.Fields, "사용처")
 
-		// 사용액 계산 (사용액이 없으면 공급가 + 부가세로 계산)
-		supAM := ocrService.CalculateAmount(image.Fields)
+		// 공급가액/부가세 분리 (사용액이 없으면 공급가 + 부가세로 계산)
+		vatSplit := ocrService.SplitVAT(image.Fields)
+		supAM := vatSplit.Supply
+		vatAM := vatSplit.VAT
 
 		issDT := ocrService.ExtractFieldValue(image.Fields, "사용일")
 
@@ -139,7 +114,7 @@ func (e *ExcelService) ConvertAsyncOCRToExcelDataWithCategory(ocrResults []*Sing
 		if result.Remarks != "" {
 			rmkDC = result.Remarks
 		} else {
-			rmkDC = e.generateDefaultRemark(issDT, userName, result.Category)
+			rmkDC = e.generateDefaultRemark(issDT, userName, result.Category, dateContext)
 		}
 
 		// ATTR_CD 설정
@@ -149,12 +124,12 @@ func (e *ExcelService) ConvertAsyncOCRToExcelDataWithCategory(ocrResults []*Sing
 		}
 
 		excelData := e.createExcelData(
-			result.Category, rmkDC, trNM, supAM, finalAttrCD,
-			issDT, payDT, bankCD, baNB, depositorDC, deptCD, empCD,
+			result.Category, rmkDC, trNM, supAM, vatAM, finalAttrCD,
+			issDT, payDT, bankCD, baNB, depositorDC, deptCD, empCD, dateContext,
 		)
 
-		log.Printf("변환된 데이터 - 사용처: %s, 사용액: %s, 사용일: %s, 카테고리: %s",
-			trNM, excelData.SUPAM, excelData.ISSDT, result.Category)
+		log.Printf("변환된 데이터 - 사용처: %s, 공급가액: %s, 부가세: %s, 사용일: %s, 카테고리: %s",
+			trNM, excelData.SUPAM, excelData.VATAM, excelData.ISSDT, result.Category)
 		allExcelData = append(allExcelData, excelData)
 	}
 
@@ -184,7 +159,7 @@ func (e *ExcelService) CreateExcelFileWithMultipleData(dataList []*ExcelData) (*
 	headers := []string{
 		"CASH_CD", "RMK_DC", "TR_NM", "SUP_AM", "VAT_AM",
 		"ATTR_CD", "ISS_DT", "PAY_DT", "BANK_CD", "BA_NB",
-		"DEPOSITOR_DC", "DEPT_CD", "EMP_CD",
+		"DEPOSITOR_DC", "DEPT_CD", "EMP_CD", "ORG_AM",
 	}
 
 	// 2행에 헤더 추가
@@ -197,9 +172,9 @@ func (e *ExcelService) CreateExcelFileWithMultipleData(dataList []*ExcelData) (*
 	for idx, data := range dataList {
 		row := 4 + idx
 		dataValues := []interface{}{
-			data.CASHCD, data.RMKDC, data.TRNM, data.SUPAM, data.VATAM,
+			data.CASHCD, data.RMKDC, data.TRNM, data.SUPAM.String(), data.VATAM.String(),
 			data.ATTRCD, data.ISSDT, data.PAYDT, data.BANKCD, data.BANB,
-			data.DEPOSITORDC, data.DEPTCD, data.EMPCD,
+			data.DEPOSITORDC, data.DEPTCD, data.EMPCD, data.ORGAM,
 		}
 
 		for i, value := range dataValues {
@@ -217,7 +192,7 @@ func (e *ExcelService) CreateExcelFileWithMultipleData(dataList []*ExcelData) (*
 		return nil, err
 	}
 
-	f.SetCellStyle("Sheet1", "A2", "M2", headerStyle)
+	f.SetCellStyle("Sheet1", "A2", "N2", headerStyle)
 
 	// 열 너비 조정
 	for i := 0; i < len(headers); i++ {
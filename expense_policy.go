@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 위반 심각도 (error는 사유 없이 내보낼 수 없음)
+const (
+	POLICY_SEVERITY_WARNING = "warning"
+	POLICY_SEVERITY_ERROR   = "error"
+)
+
+// 정책 위반 코드
+const (
+	POLICY_PER_PERSON_CAP   = "per_person_cap"   // 1인당 한도 초과
+	POLICY_RECEIPT_TOO_OLD  = "receipt_too_old"  // 사용일로부터 N일 경과
+	POLICY_WEEKEND_EXPENSE  = "weekend_expense"  // 주말 사용
+	POLICY_HOLIDAY_EXPENSE  = "holiday_expense"  // 공휴일 사용
+	POLICY_ALCOHOL_MERCHANT = "alcohol_merchant" // 주류 관련 사용처
+	POLICY_FX_RATE_MISSING  = "fx_rate_missing"  // 외화 영수증 환율 없음
+)
+
+// 위반 코드별 기본 심각도
+var defaultPolicySeverities = map[string]string{
+	POLICY_PER_PERSON_CAP:   POLICY_SEVERITY_ERROR,
+	POLICY_RECEIPT_TOO_OLD:  POLICY_SEVERITY_WARNING,
+	POLICY_WEEKEND_EXPENSE:  POLICY_SEVERITY_WARNING,
+	POLICY_HOLIDAY_EXPENSE:  POLICY_SEVERITY_WARNING,
+	POLICY_ALCOHOL_MERCHANT: POLICY_SEVERITY_WARNING,
+	POLICY_FX_RATE_MISSING:  POLICY_SEVERITY_ERROR,
+}
+
+// CategoryPolicy 카테고리별 경비 정책 (카테고리 설정의 policy 항목)
+type CategoryPolicy struct {
+	PerPersonCap int64             `json:"perPersonCap,omitempty"` // 1인당 한도 (원, 0이면 검사 안 함)
+	MaxAgeDays   int               `json:"maxAgeDays,omitempty"`   // 0이면 POLICY_MAX_RECEIPT_AGE_DAYS
+	WeekendCheck bool              `json:"weekendCheck,omitempty"` // 주말/공휴일 사용 검사
+	AlcoholCheck bool              `json:"alcoholCheck,omitempty"` // 주류 관련 사용처 검사
+	Severities   map[string]string `json:"severities,omitempty"`   // 위반 코드별 심각도 재정의
+}
+
+// PolicyViolation 정책 위반 정보
+type PolicyViolation struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// 결과 하나에 대한 경비 정책 검사
+func checkExpensePolicy(result OCRResult, now time.Time) []PolicyViolation {
+	if !isPolicyCheckEnabled() {
+		return nil
+	}
+
+	category := currentCategoryCatalog().Get(result.Category)
+	if category == nil || category.Policy == nil {
+		return nil
+	}
+	policy := category.Policy
+
+	var violations []PolicyViolation
+	add := func(code, format string, args ...interface{}) {
+		severity := defaultPolicySeverities[code]
+		if override, exists := policy.Severities[code]; exists {
+			severity = override
+		}
+		violations = append(violations, PolicyViolation{Code: code, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// 1인당 한도 (추가 이름 포함 인원수 기준)
+	if policy.PerPersonCap > 0 {
+		headcount := countHeadcount(result.AdditionalNames)
+		limit := MoneyFromWon(policy.PerPersonCap * int64(headcount))
+		if result.Amount > limit {
+			add(POLICY_PER_PERSON_CAP, "%s 1인당 한도 %s원 초과 (%s원 / %d명)",
+				category.Label, MoneyFromWon(policy.PerPersonCap).Format(), result.Amount.Format(), headcount)
+		}
+	}
+
+	issueDate, err := time.ParseInLocation("20060102", result.IssueDate, now.Location())
+	if err == nil {
+		// 사용일 경과 기간
+		maxAgeDays := policy.MaxAgeDays
+		if maxAgeDays == 0 {
+			maxAgeDays = getPolicyMaxReceiptAgeDays()
+		}
+		if maxAgeDays > 0 {
+			ageDays := int(now.Sub(issueDate).Hours() / 24)
+			if ageDays > maxAgeDays {
+				add(POLICY_RECEIPT_TOO_OLD, "사용일로부터 %d일 경과 (허용: %d일)", ageDays, maxAgeDays)
+			}
+		}
+
+		// 주말/공휴일 사용
+		if policy.WeekendCheck {
+			if weekday := issueDate.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+				add(POLICY_WEEKEND_EXPENSE, "주말(%s) 사용", koreanWeekday(weekday))
+			} else if containsString(getPolicyHolidays(), result.IssueDate) {
+				add(POLICY_HOLIDAY_EXPENSE, "공휴일(%s) 사용", result.IssueDate)
+			}
+		}
+	}
+
+	// 주류 관련 사용처
+	if policy.AlcoholCheck {
+		merchant := strings.ToLower(result.Purpose)
+		for _, keyword := range getPolicyAlcoholKeywords() {
+			if keyword != "" && strings.Contains(merchant, strings.ToLower(keyword)) {
+				add(POLICY_ALCOHOL_MERCHANT, "주류 관련 사용처로 보입니다 (키워드: %s)", keyword)
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// 결과 목록 전체에 정책 검사 결과 반영
+func applyExpensePolicy(results []OCRResult) {
+	now := time.Now()
+	for i := range results {
+		results[i].Violations = append(checkCurrencyConversion(results[i]), checkExpensePolicy(results[i], now)...)
+	}
+}
+
+// 외화 영수증 환산 여부 (카테고리 정책과 관계없이 항상 검사)
+func checkCurrencyConversion(result OCRResult) []PolicyViolation {
+	if !isUnconvertedForeignAmount(result) {
+		return nil
+	}
+	return []PolicyViolation{{
+		Code:     POLICY_FX_RATE_MISSING,
+		Severity: defaultPolicySeverities[POLICY_FX_RATE_MISSING],
+		Message:  fmt.Sprintf("%s %s 환율이 없어 원화로 환산하지 못했습니다 (사용일: %s)", result.Currency, result.OriginalAmount.Format(), result.IssueDate),
+	}}
+}
+
+// error 수준 위반이 있는 결과 수집
+func collectPolicyErrors(results []OCRResult) []OCRResult {
+	blocked := []OCRResult{}
+	for _, result := range results {
+		for _, violation := range result.Violations {
+			if violation.Severity == POLICY_SEVERITY_ERROR {
+				blocked = append(blocked, result)
+				break
+			}
+		}
+	}
+	return blocked
+}
+
+// 금액이 0원인 결과 수집 (정책 위반 사유 입력으로도 내보낼 수 없음)
+func collectZeroAmountResults(results []OCRResult) []OCRResult {
+	zeroAmount := []OCRResult{}
+	for _, result := range results {
+		if result.Amount.IsZero() {
+			zeroAmount = append(zeroAmount, result)
+		}
+	}
+	return zeroAmount
+}
+
+// 환율이 없어 원화로 환산하지 못한 외화 결과 수집 (정책 위반 사유 입력으로도 내보낼 수 없음)
+func collectUnconvertedForeignResults(results []OCRResult) []OCRResult {
+	unconverted := []OCRResult{}
+	for _, result := range results {
+		if isUnconvertedForeignAmount(result) {
+			unconverted = append(unconverted, result)
+		}
+	}
+	return unconverted
+}
+
+// 본인 + 추가 이름 수
+func countHeadcount(additionalNames string) int {
+	count := 1
+	for _, name := range strings.Split(additionalNames, ",") {
+		if strings.TrimSpace(name) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+func koreanWeekday(weekday time.Weekday) string {
+	return []string{"일", "월", "화", "수", "목", "금", "토"}[weekday]
+}
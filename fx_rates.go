@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 지원 통화
+const (
+	CURRENCY_KRW = "KRW"
+	CURRENCY_USD = "USD"
+	CURRENCY_JPY = "JPY"
+	CURRENCY_EUR = "EUR"
+)
+
+// 환율 소수점 자릿수 (1원 = FX_RATE_SCALE)
+const FX_RATE_SCALE = 1000000
+
+// 금액 텍스트에서 통화를 판단하는 표기 (앞에 있는 항목 우선)
+var currencyMarkers = []struct {
+	currency string
+	markers  []string
+}{
+	{CURRENCY_KRW, []string{"₩", "￦", "원"}},
+	{CURRENCY_USD, []string{"US$", "$", "달러"}},
+	{CURRENCY_JPY, []string{"¥", "￥", "円", "엔"}},
+	{CURRENCY_EUR, []string{"€", "유로"}},
+}
+
+// 사용처 등 일반 텍스트에서도 인식하는 통화 코드/기호
+var currencyCodePattern = regexp.MustCompile(`(?:^|[^A-Z])(KRW|USD|JPY|EUR)(?:[^A-Z]|$)`)
+var currencySymbols = map[string]string{"$": CURRENCY_USD, "¥": CURRENCY_JPY, "￥": CURRENCY_JPY, "€": CURRENCY_EUR}
+
+// FXRateFile 환율 파일 형식
+type FXRateFile struct {
+	Rates []FXRateEntry `json:"rates"`
+}
+
+// FXRateEntry 일자별 환율 (unit 단위당 원화, 예: JPY 100엔당 905.12원)
+type FXRateEntry struct {
+	Date     string      `json:"date"` // YYYY-MM-DD 또는 YYYYMMDD
+	Currency string      `json:"currency"`
+	Rate     json.Number `json:"rate"`
+	Unit     int64       `json:"unit,omitempty"` // 기본 1
+}
+
+// FXRate 조회된 환율
+type FXRate struct {
+	Currency string
+	Date     string // YYYYMMDD (영수증 사용일 이전 가장 가까운 고시일)
+	Rate     string // 원문 환율
+	Unit     int64
+	scaled   int64 // 환율 × FX_RATE_SCALE
+}
+
+// FXRateTable 파일 기반 환율표 (파일이 바뀌면 다시 로드)
+type FXRateTable struct {
+	mu      sync.RWMutex
+	rates   map[string][]FXRate // 통화별, 고시일 오름차순
+	source  string
+	modTime time.Time
+}
+
+var (
+	fxRateTable     *FXRateTable
+	fxRateTableOnce sync.Once
+	fxRateTableErr  error
+)
+
+// 전역 환율표 반환 (FX_RATES_FILE이 없으면 빈 환율표)
+func getFXRateTable() (*FXRateTable, error) {
+	fxRateTableOnce.Do(func() {
+		fxRateTable, fxRateTableErr = NewFXRateTable(getFXRatesFile())
+	})
+	return fxRateTable, fxRateTableErr
+}
+
+// 환율표 생성자 (경로가 비어 있으면 환율 없음)
+func NewFXRateTable(path string) (*FXRateTable, error) {
+	table := &FXRateTable{source: path, rates: make(map[string][]FXRate)}
+	if path == "" {
+		return table, nil
+	}
+	if err := table.reload(); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// 환율 파일 다시 읽기
+func (t *FXRateTable) reload() error {
+	info, err := os.Stat(t.source)
+	if err != nil {
+		return fmt.Errorf("환율 파일 확인 실패: %v", err)
+	}
+	data, err := os.ReadFile(t.source)
+	if err != nil {
+		return fmt.Errorf("환율 파일 읽기 실패: %v", err)
+	}
+
+	var file FXRateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("환율 파일 파싱 실패 (%s): %v", t.source, err)
+	}
+	rates, err := prepareFXRates(file.Rates)
+	if err != nil {
+		return fmt.Errorf("환율 파일 검증 실패 (%s): %v", t.source, err)
+	}
+
+	t.mu.Lock()
+	t.rates = rates
+	t.modTime = info.ModTime()
+	t.mu.Unlock()
+
+	log.Printf("환율 로드: %d개 통화 (%s)", len(rates), t.source)
+	return nil
+}
+
+// 파일이 변경되었으면 다시 로드 (실패 시 기존 환율 유지)
+func (t *FXRateTable) reloadIfChanged() {
+	if t.source == "" {
+		return
+	}
+	info, err := os.Stat(t.source)
+	if err != nil {
+		return
+	}
+
+	t.mu.RLock()
+	changed := !info.ModTime().Equal(t.modTime)
+	t.mu.RUnlock()
+
+	if changed {
+		if err := t.reload(); err != nil {
+			log.Printf("⚠️ %v (기존 환율 유지)", err)
+		}
+	}
+}
+
+// 환율 항목 검증 후 통화별로 정리
+func prepareFXRates(entries []FXRateEntry) (map[string][]FXRate, error) {
+	rates := make(map[string][]FXRate)
+	for i, entry := range entries {
+		currency := strings.ToUpper(strings.TrimSpace(entry.Currency))
+		if currency == "" || currency == CURRENCY_KRW {
+			return nil, fmt.Errorf("환율 %d: 외화 통화 코드가 필요합니다", i+1)
+		}
+		date := strings.ReplaceAll(entry.Date, "-", "")
+		if _, err := time.Parse("20060102", date); err != nil {
+			return nil, fmt.Errorf("환율 %d: 잘못된 날짜 %q", i+1, entry.Date)
+		}
+		scaled, err := parseFXRate(entry.Rate.String())
+		if err != nil {
+			return nil, fmt.Errorf("환율 %d (%s %s): %v", i+1, currency, entry.Date, err)
+		}
+		unit := entry.Unit
+		if unit == 0 {
+			unit = 1
+		}
+		if unit < 0 {
+			return nil, fmt.Errorf("환율 %d (%s %s): unit은 1 이상이어야 합니다", i+1, currency, entry.Date)
+		}
+		rates[currency] = append(rates[currency], FXRate{Currency: currency, Date: date, Rate: entry.Rate.String(), Unit: unit, scaled: scaled})
+	}
+
+	for currency, list := range rates {
+		sort.Slice(list, func(i, j int) bool { return list[i].Date < list[j].Date })
+		for i := 1; i < len(list); i++ {
+			if list[i].Date == list[i-1].Date {
+				return nil, fmt.Errorf("%s %s: 같은 날짜의 환율이 중복되었습니다", currency, list[i].Date)
+			}
+		}
+	}
+	return rates, nil
+}
+
+// 환율 문자열을 고정 소수점 정수로 변환 (소수점 6자리까지)
+func parseFXRate(text string) (int64, error) {
+	intPart, fracPart, _ := strings.Cut(strings.TrimSpace(text), ".")
+	if len(fracPart) > 6 {
+		return 0, fmt.Errorf("환율은 소수점 6자리까지 입력할 수 있습니다: %s", text)
+	}
+	fracPart += strings.Repeat("0", 6-len(fracPart))
+
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("잘못된 환율: %s", text)
+	}
+	fraction, err := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("잘못된 환율: %s", text)
+	}
+	scaled := units*FX_RATE_SCALE + fraction
+	if scaled <= 0 {
+		return 0, fmt.Errorf("환율은 0보다 커야 합니다: %s", text)
+	}
+	return scaled, nil
+}
+
+// 사용일(YYYYMMDD) 기준 환율 조회 (당일 또는 FX_RATE_MAX_STALE_DAYS 이내 직전 고시일)
+func (t *FXRateTable) Lookup(currency, issueDate string) (*FXRate, error) {
+	t.reloadIfChanged()
+
+	issuedAt, err := time.Parse("20060102", issueDate)
+	if err != nil {
+		return nil, fmt.Errorf("사용일을 알 수 없어 환율을 조회할 수 없습니다")
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	list := t.rates[currency]
+	index := sort.Search(len(list), func(i int) bool { return list[i].Date > issueDate }) - 1
+	if index < 0 {
+		return nil, fmt.Errorf("%s %s 이전 환율이 없습니다", currency, issueDate)
+	}
+
+	rate := list[index]
+	rateDate, _ := time.Parse("20060102", rate.Date)
+	if staleDays := int(issuedAt.Sub(rateDate).Hours() / 24); staleDays > getFXRateMaxStaleDays() {
+		return nil, fmt.Errorf("%s %s 기준 최근 환율(%s)이 %d일 전입니다", currency, issueDate, rate.Date, staleDays)
+	}
+	return &rate, nil
+}
+
+// 외화 금액을 원화로 환산 (원 단위 반올림)
+func (r *FXRate) Convert(amount Money) Money {
+	numerator := new(big.Int).Mul(big.NewInt(int64(amount)), big.NewInt(r.scaled))
+	denominator := big.NewInt(r.Unit * FX_RATE_SCALE * MONEY_SCALE)
+
+	negative := numerator.Sign() < 0
+	numerator.Abs(numerator)
+	numerator.Add(numerator, new(big.Int).Quo(denominator, big.NewInt(2)))
+	won := numerator.Quo(numerator, denominator).Int64()
+	if negative {
+		won = -won
+	}
+	return MoneyFromWon(won)
+}
+
+// 금액 텍스트의 통화 판단 (표기가 없으면 빈 값)
+func detectAmountCurrency(text string) string {
+	if match := currencyCodePattern.FindStringSubmatch(strings.ToUpper(text)); match != nil {
+		return match[1]
+	}
+	for _, entry := range currencyMarkers {
+		for _, marker := range entry.markers {
+			if strings.Contains(text, marker) {
+				return entry.currency
+			}
+		}
+	}
+	return ""
+}
+
+// 사용처 등 일반 텍스트의 통화 판단 (통화 코드와 기호만 인정)
+func detectTextCurrency(text string) string {
+	if match := currencyCodePattern.FindStringSubmatch(text); match != nil {
+		return match[1]
+	}
+	for symbol, currency := range currencySymbols {
+		if strings.Contains(text, symbol) {
+			return currency
+		}
+	}
+	return ""
+}
+
+// OCR 필드에서 통화 판단 (금액 필드 우선, 없으면 사용처, 기본 KRW)
+func (s *OCRService) DetectCurrency(fields []Field) string {
+	for _, name := range []string{"사용액", "공급가", "부가세"} {
+		if currency := detectAmountCurrency(s.ExtractFieldValue(fields, name)); currency != "" {
+			return currency
+		}
+	}
+	if currency := detectTextCurrency(s.ExtractFieldValue(fields, "사용처")); currency != "" {
+		return currency
+	}
+	return CURRENCY_KRW
+}
+
+// 외화 결과를 사용일 환율로 원화 환산 (원래 금액/통화는 보존, 외화 영수증은 부가세 없음)
+// 환율이 없으면 원금액을 그대로 두고 환율 없음(fx_rate_missing)으로 표시됩니다.
+func applyCurrencyConversion(result *OCRResult, currency string) {
+	result.Currency = currency
+	result.OriginalAmount = result.Amount
+	result.SupplyAmount, result.VATAmount = result.Amount, 0
+	result.FXRate, result.FXRateDate = "", ""
+	result.TaxExempt = true
+
+	table, err := getFXRateTable()
+	if err == nil {
+		var rate *FXRate
+		if rate, err = table.Lookup(currency, result.IssueDate); err == nil {
+			result.Amount = rate.Convert(result.OriginalAmount)
+			result.SupplyAmount = result.Amount
+			result.FXRate = rate.Rate
+			result.FXRateDate = rate.Date
+			if rate.Unit != 1 {
+				result.FXRate = fmt.Sprintf("%s/%d", rate.Rate, rate.Unit)
+			}
+			log.Printf("💱 외화 환산: %s %s × %s (%s) = %s원 - 파일: %s",
+				currency, result.OriginalAmount.Format(), result.FXRate, rate.Date, result.Amount.Format(), result.FileName)
+			return
+		}
+	}
+	log.Printf("⚠️ 외화 환산 실패 (%s %s): %v - 원금액 유지, 파일: %s", currency, result.OriginalAmount.Format(), err, result.FileName)
+}
+
+// 환율이 없어 외화 금액이 그대로 남은 결과인지 확인 (원화 열에 쓰면 안 됨)
+func isUnconvertedForeignAmount(result OCRResult) bool {
+	return result.Currency != "" && result.Currency != CURRENCY_KRW && result.FXRate == ""
+}
+
+// 내보낼 결과의 통화/환율을 서버 기준으로 다시 계산 (클라이언트가 보낸 통화/환율은 사용하지 않음)
+// OCR 캐시에 원본 결과가 있으면 그 필드로 통화를 다시 판단합니다.
+func reapplyCurrencyConversion(results []OCRResult) {
+	cache := getOCRResultCache()
+	ocrService := currentOCRService()
+	for i := range results {
+		result := &results[i]
+		currency := strings.ToUpper(strings.TrimSpace(result.Currency))
+		if cache != nil && result.ImageHash != "" {
+			if cached, ok := cache.Peek(result.ImageHash); ok {
+				currency = ocrService.DetectCurrency(cached.Fields)
+			}
+		}
+
+		if currency == "" || currency == CURRENCY_KRW {
+			// 원화 영수증을 외화로 보낸 경우 원금액으로 되돌리고 부가세 다시 분리
+			if result.Currency != "" && result.Currency != CURRENCY_KRW && !result.OriginalAmount.IsZero() {
+				log.Printf("⚠️ 원화 영수증의 외화 표시 무시 (%s) - 파일: %s", result.Currency, result.FileName)
+				split := splitVAT(result.OriginalAmount, 0, 0, result.Purpose)
+				result.Amount, result.SupplyAmount, result.VATAmount, result.TaxExempt = split.Total, split.Supply, split.VAT, split.TaxExempt
+			}
+			result.Currency, result.OriginalAmount, result.FXRate, result.FXRateDate = "", 0, "", ""
+			continue
+		}
+
+		if !result.OriginalAmount.IsZero() {
+			result.Amount = result.OriginalAmount
+		}
+		applyCurrencyConversion(result, currency)
+	}
+}
+
+// 환산 내역 (Excel 감사 열, 예: "USD 12.50 @ 1330.5 (20240304)")
+func formatCurrencyAudit(result OCRResult) string {
+	if result.Currency == "" || result.Currency == CURRENCY_KRW {
+		return ""
+	}
+	if result.FXRate == "" {
+		return fmt.Sprintf("%s %s (환율 없음)", result.Currency, result.OriginalAmount.Format())
+	}
+	return fmt.Sprintf("%s %s @ %s (%s)", result.Currency, result.OriginalAmount.Format(), result.FXRate, result.FXRateDate)
+}
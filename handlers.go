@@ -1,87 +1,256 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/xuri/excelize/v2"
 )
 
+// OCR 업로드 요청 (폼 파싱 결과)
+type ocrUpload struct {
+	Request     UploadRequest
+	Metadata    map[int]map[string]string
+	ImageFiles  []ImageFileWithCategory
+	DateContext DateTimeContext // 사용일 추론 기준 (업로드 일시, 청구 기간)
+	BatchID     string          // 업로드 식별자 (내보내기 이력의 배치 키)
+}
+
 // OCR 처리 핸들러
 func handleOCRProcess(c *fiber.Ctx) error {
-	// 폼 데이터 파싱
-	var req UploadRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "폼 데이터 파싱 실패: " + err.Error(),
+	upload, err := parseOCRUpload(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(uploadErrorResponse(err))
+	}
+
+	// 비동기 OCR 처리
+	ocrService, err := getOCRService()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "OCR 서비스 초기화 실패: " + err.Error(),
+		})
+	}
+	ocrResults, err := ocrService.ProcessMultipleImagesAsyncWithCategory(upload.ImageFiles, upload.DateContext)
+	if ocrResults == nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "OCR 처리 실패: " + err.Error(),
 		})
 	}
 
-	// 다중 파일 업로드 처리
-	form, err := c.MultipartForm()
+	// 결과 변환 (실패한 파일은 별도로 보고)
+	results := convertOCRResults(ocrResults, upload)
+	failures := collectOCRFailures(ocrResults)
+
+	// 업로드 순서 기준으로 중복 의심 영수증 표시 (정렬 전)
+	markDuplicateReceipts(results)
+
+	// 날짜순 정렬
+	sortResultsByIssueDate(results)
+
+	response := OCRProcessResponse{
+		Success:   len(results) > 0,
+		Total:     len(ocrResults),
+		Succeeded: len(results),
+		CacheHits: countOCRCacheHits(results),
+		Data:      results,
+		Failures:  failures,
+	}
+
+	return c.Status(buildOCRResponseStatus(&response)).JSON(response)
+}
+
+// 성공/실패 건수에 따른 응답 상태 코드와 메시지 결정
+// 전체 성공 200, 부분 성공 207, 전체 실패 시 재시도 가능 여부에 따라 503/422
+func buildOCRResponseStatus(response *OCRProcessResponse) int {
+	failedCount := len(response.Failures)
+
+	switch {
+	case failedCount == 0:
+		response.Message = fmt.Sprintf("%d개 파일의 OCR 처리가 완료되었습니다", response.Succeeded)
+		return fiber.StatusOK
+	case response.Succeeded > 0:
+		response.Message = fmt.Sprintf("%d개 중 %d개 파일의 OCR 처리가 완료되었습니다 (실패 %d개)",
+			response.Total, response.Succeeded, failedCount)
+		return fiber.StatusMultiStatus
+	}
+
+	response.Error = "모든 이미지의 OCR 처리에 실패했습니다"
+	response.Message = response.Error
+	for _, failure := range response.Failures {
+		if !failure.Retryable {
+			return fiber.StatusUnprocessableEntity
+		}
+	}
+	return fiber.StatusServiceUnavailable
+}
+
+// OCR 작업 제출 핸들러 (즉시 작업 ID 반환)
+func handleOCRJobSubmit(c *fiber.Ctx) error {
+	upload, err := parseOCRUpload(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "멀티파트 폼 파싱 실패: " + err.Error(),
+		return c.Status(fiber.StatusBadRequest).JSON(uploadErrorResponse(err))
+	}
+
+	ocrService, err := getOCRService()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "OCR 서비스 초기화 실패: " + err.Error(),
 		})
 	}
+	job := getOCRJobStore().Start(ocrService, upload)
+	snapshot := job.Snapshot()
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success":   true,
+		"jobId":     snapshot.ID,
+		"statusUrl": "/api/jobs/" + snapshot.ID,
+		"job":       snapshot,
+	})
+}
 
-	files := form.File["images"]
-	if len(files) == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "이미지 파일을 찾을 수 없습니다",
+// OCR 작업 상태 조회 핸들러
+func handleOCRJobStatus(c *fiber.Ctx) error {
+	job, exists := getOCRJobStore().Get(c.Params("id"))
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "작업을 찾을 수 없습니다 (만료되었거나 존재하지 않음)",
 		})
 	}
 
-	// 파일 개수 제한 체크
-	if len(files) > getMaxFiles() {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": fmt.Sprintf("한 번에 최대 %d개의 파일만 업로드할 수 있습니다", getMaxFiles()),
+	return c.JSON(job.Snapshot())
+}
+
+// OCR 작업 취소 핸들러
+func handleOCRJobCancel(c *fiber.Ctx) error {
+	job, exists := getOCRJobStore().Get(c.Params("id"))
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "작업을 찾을 수 없습니다 (만료되었거나 존재하지 않음)",
 		})
 	}
 
-	// 메타데이터 추출 (통합 함수)
-	metadata := extractFormMetadata(form, len(files))
+	if !job.Cancel() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "이미 종료된 작업입니다",
+			"job":   job.Snapshot(),
+		})
+	}
 
-	// 파일 처리 및 OCR 호출
-	imageFiles, err := prepareImageFiles(files, metadata)
-	if err != nil {
+	return c.JSON(job.Snapshot())
+}
+
+// OCR 작업 진행 이벤트 스트림 핸들러 (Server-Sent Events)
+func handleOCRJobEvents(c *fiber.Ctx) error {
+	job, exists := getOCRJobStore().Get(c.Params("id"))
+	if !exists {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "작업을 찾을 수 없습니다 (만료되었거나 존재하지 않음)",
+		})
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// 구독 후 스냅샷을 보내야 그 사이 이벤트가 누락되지 않음
+	events, unsubscribe := job.Subscribe()
+	snapshot := job.Snapshot()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		if err := writeSSEEvent(w, "snapshot", snapshot); err != nil {
+			return
+		}
+
+		keepAlive := time.NewTicker(15 * time.Second)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					// 작업 종료: 최종 결과 전달
+					writeSSEEvent(w, "done", job.Snapshot())
+					return
+				}
+				if err := writeSSEEvent(w, event.Type, event); err != nil {
+					log.Printf("SSE 클라이언트 연결 종료: 작업 %s", snapshot.ID)
+					return
+				}
+			case <-keepAlive.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// 검토 대기열 조회 핸들러 (status: pending(기본) | confirmed | corrected | all)
+func handleReviewList(c *fiber.Ctx) error {
+	status := strings.ToLower(c.Query("status", REVIEW_STATUS_PENDING))
+	switch status {
+	case "all":
+		status = ""
+	case REVIEW_STATUS_PENDING, REVIEW_STATUS_CONFIRMED, REVIEW_STATUS_CORRECTED:
+	default:
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
+			"error": "status는 pending, confirmed, corrected, all 중 하나여야 합니다",
 		})
 	}
 
-	// 비동기 OCR 처리
-	ocrService := NewOCRService()
-	ocrResults, err := ocrService.ProcessMultipleImagesAsyncWithCategory(imageFiles)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "OCR 처리 실패: " + err.Error(),
+	items := getReviewStore().List(status)
+	return c.JSON(fiber.Map{
+		"threshold": getReviewConfidenceThreshold(),
+		"total":     len(items),
+		"items":     items,
+	})
+}
+
+// 검토 확인/수정 핸들러 (fields가 비어 있으면 OCR 값 그대로 확인)
+func handleReviewSubmit(c *fiber.Ctx) error {
+	var req ReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "요청 본문 파싱 실패: " + err.Error(),
 		})
 	}
 
-	// 결과 변환
-	results := convertOCRResults(ocrResults, req.UserName, metadata)
-	if len(results) == 0 {
+	item, err := getReviewStore().Review(c.Params("id"), req)
+	switch {
+	case errors.Is(err, errReviewNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case err != nil && item.ID == "": // 입력 검증 실패 (저장 실패는 항목이 함께 반환됨)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case err != nil:
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "모든 이미지의 OCR 처리에 실패했습니다",
+			"error": "검토 결과 저장 실패: " + err.Error(),
 		})
 	}
 
-	// 날짜순 정렬
-	sortResultsByIssueDate(results)
-
-	return c.JSON(OCRProcessResponse{
-		Success: true,
-		Message: fmt.Sprintf("%d개 파일의 OCR 처리가 완료되었습니다", len(results)),
-		Data:    results,
-	})
+	log.Printf("✅ 검토 완료: %s (%s, 검토자: %s)", item.FileName, item.Status, item.Reviewer)
+	return c.JSON(item)
 }
 
 // Excel 다운로드 핸들러
@@ -108,11 +277,83 @@ func handleExcelDownload(c *fiber.Ctx) error {
 		})
 	}
 
+	// 외화 영수증 환산은 서버 환율표 기준으로 다시 계산
+	reapplyCurrencyConversion(ocrResults)
+
+	// 업로드 일시가 없는 결과(이전 버전 클라이언트)의 사용일 추론 기준 (다운로드 일시, 청구 기간)
+	dateContext, err := NewDateTimeContext(time.Now(), req.ClaimPeriodStart, req.ClaimPeriodEnd)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// 신뢰도 검토 결과 반영 (수정 값 적용 후 중복/정책 검사)
+	if pending := applyReviewResults(ocrResults, dateContext); len(pending) > 0 {
+		switch policy := getReviewExportPolicy(); {
+		case policy == REVIEW_POLICY_BLOCK && !req.AllowUnreviewed:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":      fmt.Sprintf("검토 대기 중인 영수증 %d건이 있어 Excel 생성을 중단했습니다", len(pending)),
+				"unreviewed": pending,
+			})
+		case policy != REVIEW_POLICY_OFF:
+			log.Printf("⚠️ 검토 대기 영수증 %d건 포함하여 Excel 생성 (정책: %s, 허용: %t)", len(pending), policy, req.AllowUnreviewed)
+			c.Set("X-Unreviewed-Count", strconv.Itoa(len(pending)))
+		}
+	}
+
+	// 중복 의심 영수증 확인 (클라이언트가 보낸 표시는 신뢰하지 않고 다시 계산)
+	policy := getDuplicateExportPolicy()
+	if policy != DUPLICATE_POLICY_OFF {
+		markDuplicateReceipts(ocrResults)
+		duplicates := collectDuplicateReceipts(ocrResults)
+		if len(duplicates) > 0 {
+			if policy == DUPLICATE_POLICY_BLOCK && !req.AllowDuplicates {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error":      fmt.Sprintf("중복 의심 영수증 %d건이 있어 Excel 생성을 중단했습니다", len(duplicates)),
+					"duplicates": duplicates,
+				})
+			}
+			log.Printf("⚠️ 중복 의심 영수증 %d건 포함하여 Excel 생성 (정책: %s, 허용: %t)", len(duplicates), policy, req.AllowDuplicates)
+			c.Set("X-Duplicate-Count", strconv.Itoa(len(duplicates)))
+		}
+	}
+
+	// 경비 정책 검사 (error 수준 위반은 사유가 있어야 내보내기 허용)
+	applyExpensePolicy(ocrResults)
+	if unconverted := collectUnconvertedForeignResults(ocrResults); len(unconverted) > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":      fmt.Sprintf("환율이 없어 원화로 환산하지 못한 외화 영수증 %d건은 사유를 입력해도 내보낼 수 없습니다", len(unconverted)),
+			"violations": unconverted,
+		})
+	}
+	if blocked := collectPolicyErrors(ocrResults); len(blocked) > 0 {
+		overrideReason := strings.TrimSpace(req.OverrideReason)
+		if zeroAmount := collectZeroAmountResults(blocked); len(zeroAmount) > 0 {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      fmt.Sprintf("금액이 0원인 정책 위반 영수증 %d건은 사유를 입력해도 내보낼 수 없습니다", len(zeroAmount)),
+				"violations": zeroAmount,
+			})
+		}
+		if overrideReason == "" {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":      fmt.Sprintf("경비 정책 위반 %d건이 있어 Excel 생성을 중단했습니다. 사유를 입력하면 내보낼 수 있습니다", len(blocked)),
+				"violations": blocked,
+			})
+		}
+		log.Printf("⚠️ 경비 정책 위반 %d건 사유 입력 후 Excel 생성 (사용자: %s, 사유: %s)", len(blocked), req.UserName, overrideReason)
+	}
+
 	// 환경변수 기본값 설정
 	setDefaultValues(&req.UploadRequest)
 
 	// OCR 결과를 Excel 데이터로 변환
-	allExcelData := convertResultsToExcelData(ocrResults, &req.UploadRequest)
+	allExcelData, err := convertResultsToExcelData(ocrResults, &req.UploadRequest)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": "Excel 데이터 변환 실패: " + err.Error(),
+		})
+	}
 
 	// Excel 파일 생성
 	excelService := NewExcelService()
@@ -124,12 +365,98 @@ func handleExcelDownload(c *fiber.Ctx) error {
 	}
 	defer excelFile.Close()
 
+	// 이후 업로드의 중복 확인을 위해 내보낸 영수증 기록
+	recordExportedReceipts(ocrResults, req.UserName)
+
 	// 파일 다운로드 응답
 	return sendExcelFile(c, excelFile, len(allExcelData))
 }
 
 // 헬퍼 함수들
 
+// 업로드 폼 파싱 (폼 필드, 파일 개수 제한, 메타데이터, 파일 읽기)
+func parseOCRUpload(c *fiber.Ctx) (*ocrUpload, error) {
+	// 폼 데이터 파싱
+	var req UploadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, fmt.Errorf("폼 데이터 파싱 실패: %v", err)
+	}
+
+	// 다중 파일 업로드 처리
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, fmt.Errorf("멀티파트 폼 파싱 실패: %v", err)
+	}
+
+	// 사용일 추론 기준 (업로드 일시, 청구 기간)
+	dateContext, err := NewDateTimeContext(time.Now(), req.ClaimPeriodStart, req.ClaimPeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	files := form.File["images"]
+	if len(files) == 0 {
+		return nil, fmt.Errorf("이미지 파일을 찾을 수 없습니다")
+	}
+
+	// 파일 개수 제한 체크
+	if len(files) > getMaxFiles() {
+		return nil, fmt.Errorf("한 번에 최대 %d개의 파일만 업로드할 수 있습니다", getMaxFiles())
+	}
+
+	// 메타데이터 추출 (통합 함수)
+	metadata := extractFormMetadata(form, len(files))
+
+	// 카테고리 유효성 및 카테고리별 필수 입력 확인
+	catalog := currentCategoryCatalog()
+	for i, file := range files {
+		if err := catalog.ValidateMetadata(metadata[i]["category"], metadata[i]); err != nil {
+			return nil, fmt.Errorf("파일 '%s': %v", file.Filename, err)
+		}
+	}
+
+	// 파일 처리 (다중 페이지 PDF/TIFF는 페이지별로 분리)
+	imageFiles, originals, err := prepareImageFiles(files, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// 파일 개수 제한은 분리된 페이지 기준
+	if len(imageFiles) > getMaxFiles() {
+		return nil, fmt.Errorf("한 번에 최대 %d페이지까지 처리할 수 있습니다 (업로드 %d개 파일, 총 %d페이지)",
+			getMaxFiles(), len(files), len(imageFiles))
+	}
+
+	// 모든 검증을 통과한 업로드만 원본 보관
+	for _, original := range originals {
+		archiveUploadOriginal(original)
+	}
+
+	// 페이지 순번 기준 메타데이터 (페이지는 원본 파일의 메타데이터 공유)
+	pageMetadata := make(map[int]map[string]string, len(imageFiles))
+	for i, imageFile := range imageFiles {
+		pageMetadata[i] = metadata[imageFile.UploadIndex]
+	}
+
+	return &ocrUpload{
+		Request:     req,
+		Metadata:    pageMetadata,
+		ImageFiles:  imageFiles,
+		DateContext: dateContext,
+		BatchID:     uuid.New().String(),
+	}, nil
+}
+
+// 업로드 검증 실패 응답 (파일 검증 실패는 파일별 오류 포함)
+func uploadErrorResponse(err error) fiber.Map {
+	response := fiber.Map{"error": err.Error()}
+	var validationErr *UploadValidationError
+	if errors.As(err, &validationErr) {
+		response["files"] = validationErr.Files
+	}
+	return response
+}
+
 // 폼 메타데이터 추출
 func extractFormMetadata(form *multipart.Form, fileCount int) map[int]map[string]string {
 	metadata := make(map[int]map[string]string)
@@ -146,7 +473,7 @@ func extractFormMetadata(form *multipart.Form, fileCount int) map[int]map[string
 			} else {
 				// 기본값 설정
 				if field == "category" {
-					metadata[i][field] = "6130" // 기본값: 석식
+					metadata[i][field] = currentCategoryCatalog().DefaultCode()
 				} else {
 					metadata[i][field] = ""
 				}
@@ -157,49 +484,94 @@ func extractFormMetadata(form *multipart.Form, fileCount int) map[int]map[string
 	return metadata
 }
 
-// 이미지 파일 준비
-func prepareImageFiles(files []*multipart.FileHeader, metadata map[int]map[string]string) ([]ImageFileWithCategory, error) {
+// 이미지 파일 준비 (다중 페이지 문서는 페이지마다 하나씩, 보관할 업로드 원본도 함께 반환)
+func prepareImageFiles(files []*multipart.FileHeader, metadata map[int]map[string]string) ([]ImageFileWithCategory, []ImageFile, error) {
 	var imageFiles []ImageFileWithCategory
 
-	for i, file := range files {
-		// 파일 형식 검증
-		if !isSupportedImageFormat(file.Filename) {
-			return nil, fmt.Errorf("파일 '%s'는 지원하지 않는 형식입니다", file.Filename)
-		}
+	var invalidFiles []UploadFileError
+	var originals []ImageFile // 보관할 업로드 원본 (호출자가 검증을 마친 뒤 저장)
 
+	for i, file := range files {
 		// 파일 읽기
 		fileReader, err := file.Open()
 		if err != nil {
-			return nil, fmt.Errorf("파일 '%s' 읽기 실패: %v", file.Filename, err)
+			return nil, nil, fmt.Errorf("파일 '%s' 읽기 실패: %v", file.Filename, err)
 		}
 
 		fileData, err := io.ReadAll(fileReader)
 		fileReader.Close()
 		if err != nil {
-			return nil, fmt.Errorf("파일 '%s' 데이터 읽기 실패: %v", file.Filename, err)
+			return nil, nil, fmt.Errorf("파일 '%s' 데이터 읽기 실패: %v", file.Filename, err)
+		}
+
+		// 파일 형식 검증 (확장자가 아닌 내용 기준, 잘못된 파일은 모아서 한 번에 안내)
+		detected, err := inspectImageFile(fileData)
+		if err != nil {
+			invalidFiles = append(invalidFiles, UploadFileError{FileName: file.Filename, Error: err.Error()})
+			continue
+		}
+		if IMAGE_FORMAT_EXTENSIONS[strings.ToLower(filepath.Ext(file.Filename))] != detected.Format {
+			log.Printf("⚠️ 파일 '%s': 확장자와 내용이 다릅니다. 내용 기준 형식 %s로 처리 (%dx%d)", file.Filename, detected.Format, detected.Width, detected.Height)
 		}
 
 		// 메타데이터 가져오기
 		meta := metadata[i]
-		imageFiles = append(imageFiles, ImageFileWithCategory{
-			ImageFile: ImageFile{
-				Data:     fileData,
-				Filename: file.Filename,
-			},
-			Category:        meta["category"],
-			Remarks:         meta["remarks"],
-			BusinessContent: meta["business_content"],
-			Purpose:         meta["purpose"],
-		})
+		imageFile := ImageFile{Data: fileData, Filename: file.Filename, SourceFile: file.Filename, Page: 1, PageCount: 1, Format: detected.Format}
+
+		// HEIC/WebP는 OCR 지원 형식으로 변환 (원본은 OriginalData로 보관)
+		if needsImageConversion(detected.Format) {
+			converted, _, err := convertToOCRImage(file.Filename, fileData, detected.Format)
+			if err == nil {
+				detected, err = inspectImageFile(converted)
+			}
+			if err != nil {
+				invalidFiles = append(invalidFiles, UploadFileError{FileName: file.Filename, Error: err.Error()})
+				continue
+			}
+			imageFile.OriginalData, imageFile.OriginalFormat = fileData, imageFile.Format
+			imageFile.Data, imageFile.Format = converted, detected.Format
+		}
+		originals = append(originals, imageFile)
+		pageFiles := []ImageFile{imageFile}
+		if pages := splitDocumentPages(imageFile); len(pages) > 1 {
+			pageFiles = pageFiles[:0]
+			for _, page := range pages {
+				pageFiles = append(pageFiles, ImageFile{
+					Data:       page.Data,
+					Filename:   documentPageFilename(file.Filename, page.Number),
+					SourceFile: file.Filename,
+					Page:       page.Number,
+					PageCount:  len(pages),
+					Format:     detected.Format,
+				})
+			}
+		}
+
+		for _, pageFile := range pageFiles {
+			imageFiles = append(imageFiles, ImageFileWithCategory{
+				ImageFile:       pageFile,
+				UploadIndex:     i,
+				Category:        meta["category"],
+				Remarks:         meta["remarks"],
+				BusinessContent: meta["business_content"],
+				Purpose:         meta["purpose"],
+			})
+		}
 	}
 
-	return imageFiles, nil
+	if len(invalidFiles) > 0 {
+		return nil, nil, &UploadValidationError{Files: invalidFiles}
+	}
+	return imageFiles, originals, nil
 }
 
 // OCR 결과 변환
-func convertOCRResults(ocrResults []*SingleImageOCRResultWithCategory, userName string, metadata map[int]map[string]string) []OCRResult {
+func convertOCRResults(ocrResults []*SingleImageOCRResultWithCategory, upload *ocrUpload) []OCRResult {
 	var results []OCRResult
+	userName, metadata := upload.Request.UserName, upload.Metadata
+	uploadedAt := upload.DateContext.Reference
 	excelService := NewExcelService()
+	ocrServiceInstance := currentOCRService()
 
 	for _, result := range ocrResults {
 		if result.SingleImageOCRResult.Error != nil || result.SingleImageOCRResult.Response == nil || result.SingleImageOCRResult.Response.InferResult != "SUCCESS" {
@@ -208,60 +580,140 @@ func convertOCRResults(ocrResults []*SingleImageOCRResultWithCategory, userName
 		}
 
 		image := result.SingleImageOCRResult.Response
-		ocrServiceInstance := NewOCRService()
 
 		// 필드에서 값 추출
 		purpose := ocrServiceInstance.ExtractFieldValue(image.Fields, "사용처")
 
 		// 사용액 계산 (사용액이 없으면 공급가 + 부가세로 계산)
 		amount := ocrServiceInstance.CalculateAmount(image.Fields)
-		amount = excelService.cleanAmountText(amount)
 
-		// 원본 사용일 (시간 정보 포함) 저장 - 표준 형식으로 변환
-		originalIssueDate := ocrServiceInstance.ExtractFieldValue(image.Fields, "사용일")
-		formattedOriginalIssueDate := formatDateTimeToStandard(originalIssueDate)
+		// 공급가액/부가세 분리 (OCR 필드 우선, 없으면 10/110 계산)
+		vatSplit := ocrServiceInstance.SplitVAT(image.Fields)
 
-		// 변환된 사용일 (YYYYMMDD 형식)
-		issueDate := excelService.convertDateFormat(originalIssueDate)
+		// 원본 사용일 (시간 정보 포함) 파싱 - 인식하지 못하면 원문 유지
+		// 2자리/누락 연도와 시간만 있는 영수증은 업로드일/청구 기간 기준으로 추론
+		originalIssueDate := ocrServiceInstance.ExtractFieldValue(image.Fields, "사용일")
+		formattedOriginalIssueDate, issueDate := originalIssueDate, originalIssueDate
+		issueDateParse := &DateTimeParseInfo{Components: []string{}, Guessed: true}
+		if parsed, err := ParseDateTimeWithContext(originalIssueDate, upload.DateContext); err == nil {
+			formattedOriginalIssueDate = parsed.Standard() // 표준 형식 (YYYY/MM/DD HH:MM)
+			issueDate = parsed.YYYYMMDD()                  // 변환된 사용일 (YYYYMMDD 형식)
+			issueDateParse = parsed.Info()
+			if len(parsed.Inferred) > 0 {
+				log.Printf("🔎 사용일 추론: %q → %s (추론 항목: %s) - 파일: %s", originalIssueDate, parsed.Standard(),
+					strings.Join(parsed.Inferred, ", "), result.SingleImageOCRResult.ImageName)
+			}
+		} else {
+			log.Printf("⚠️ 사용일 인식 실패: %v - 파일: %s", err, result.SingleImageOCRResult.ImageName)
+		}
 
 		payDate := excelService.calculatePaymentDate()
 
 		// 비고 생성
-		remark := generateRemark(result, userName, metadata[result.SingleImageOCRResult.ImageIndex], issueDate, excelService)
+		remark := generateRemark(result, userName, metadata[result.SingleImageOCRResult.ImageIndex], issueDate, upload.DateContext, excelService)
 
-		// 시간 기반 카테고리 조정 로그 출력 (조정된 상태)
+		// 규칙 기반 카테고리 조정 로그 출력 (조정된 상태)
 		log.Printf("📋 최종 카테고리: %s (%s) - 파일: %s",
-			excelService.getCategoryLabel(result.Category),
+			getCategoryLabel(result.Category),
 			result.Category,
 			result.SingleImageOCRResult.ImageName)
 
-		results = append(results, OCRResult{
+		// 다중 페이지 문서의 페이지면 원본 파일과 페이지 번호 표시
+		source := upload.ImageFiles[result.SingleImageOCRResult.ImageIndex].ImageFile
+		if source.PageCount <= 1 {
+			source.SourceFile, source.Page, source.PageCount = "", 0, 0
+		}
+
+		converted := OCRResult{
 			FileName:          result.SingleImageOCRResult.ImageName,
-			Category:          result.Category, // 시간 기반으로 조정된 카테고리
+			SourceFile:        source.SourceFile,
+			Page:              source.Page,
+			PageCount:         source.PageCount,
+			Category:          result.Category, // 규칙 기반으로 조정된 카테고리
+			CategoryRule:      result.CategoryRule,
 			Remark:            remark,
 			Purpose:           purpose,
 			Amount:            amount,
+			SupplyAmount:      vatSplit.Supply,
+			VATAmount:         vatSplit.VAT,
+			TaxExempt:         vatSplit.TaxExempt,
 			IssueDate:         issueDate,
 			PayDate:           payDate,
 			OriginalIssueDate: formattedOriginalIssueDate, // 표준 형식으로 변환된 원본 사용일
-		})
+			IssueDateParse:    issueDateParse,
+			CacheHit:          result.SingleImageOCRResult.CacheHit,
+			ImageHash:         result.SingleImageOCRResult.ImageHash,
+			BatchID:           upload.BatchID,
+			UploadedAt:        &uploadedAt,
+			ClaimPeriodStart:  upload.Request.ClaimPeriodStart,
+			ClaimPeriodEnd:    upload.Request.ClaimPeriodEnd,
+			AdditionalNames:   metadata[result.SingleImageOCRResult.ImageIndex]["additional_names"],
+		}
+
+		// 외화 영수증은 사용일 환율로 원화 환산
+		if currency := ocrServiceInstance.DetectCurrency(image.Fields); currency != CURRENCY_KRW {
+			applyCurrencyConversion(&converted, currency)
+		}
+
+		// 신뢰도가 낮은 필드는 검토 대기열에 등록 (이미 검토된 이미지는 수정 값 반영)
+		queueLowConfidenceFields(&converted, image.Fields, userName, upload.DateContext)
+		results = append(results, converted)
 	}
 
+	// 경비 정책 검사
+	applyExpensePolicy(results)
+
 	return results
 }
 
-// 비고 생성 로직
-func generateRemark(result *SingleImageOCRResultWithCategory, userName string, meta map[string]string, issueDate string, excelService *ExcelService) string {
-	if result.Category == "6320" { // 국내출장
-		businessContent := meta["business_content"]
-		purposeText := meta["purpose"]
+// 캐시로 처리된 결과 수 집계
+func countOCRCacheHits(results []OCRResult) int {
+	count := 0
+	for _, result := range results {
+		if result.CacheHit {
+			count++
+		}
+	}
+	return count
+}
+
+// 중복 의심 항목 목록 수집
+func collectDuplicateReceipts(results []OCRResult) []OCRResult {
+	duplicates := []OCRResult{}
+	for _, result := range results {
+		if result.Duplicate != nil {
+			duplicates = append(duplicates, result)
+		}
+	}
+	return duplicates
+}
+
+// 실패한 이미지 목록 수집
+func collectOCRFailures(ocrResults []*SingleImageOCRResultWithCategory) []OCRFailure {
+	failures := []OCRFailure{}
+	for _, result := range ocrResults {
+		if result.SingleImageOCRResult.Error != nil {
+			failures = append(failures, newOCRFailure(&result.SingleImageOCRResult))
+		}
+	}
+	return failures
+}
 
+// 비고 생성 로직
+func generateRemark(result *SingleImageOCRResultWithCategory, userName string, meta map[string]string, issueDate string, dateContext DateTimeContext, excelService *ExcelService) string {
+	// 필수 입력이 있는 카테고리(국내출장 등)는 입력값으로 템플릿 적용
+	catalog := currentCategoryCatalog()
+	if category := catalog.Get(result.Category); category != nil && len(category.RequiredFields) > 0 {
 		finalUserName := userName
 		if additionalNames := meta["additional_names"]; additionalNames != "" {
 			finalUserName = fmt.Sprintf("%s,%s", userName, additionalNames)
 		}
 
-		return fmt.Sprintf("%s_%s_%s", businessContent, finalUserName, purposeText)
+		return catalog.RenderRemark(result.Category, RemarkValues{
+			Names:           finalUserName,
+			BusinessContent: meta["business_content"],
+			Purpose:         meta["purpose"],
+		})
 	}
 
 	// 일반 카테고리 처리
@@ -275,7 +727,7 @@ func generateRemark(result *SingleImageOCRResultWithCategory, userName string, m
 		finalUserName = fmt.Sprintf("%s,%s", userName, additionalNames)
 	}
 
-	return excelService.generateDefaultRemark(issueDate, finalUserName, result.Category)
+	return excelService.generateDefaultRemark(issueDate, finalUserName, result.Category, dateContext)
 }
 
 // 결과를 날짜순으로 정렬
@@ -307,17 +759,21 @@ func setDefaultValues(req *UploadRequest) {
 	}
 }
 
-// OCR 결과를 Excel 데이터로 변환
-func convertResultsToExcelData(ocrResults []OCRResult, req *UploadRequest) []*ExcelData {
+// OCR 결과를 Excel 데이터로 변환 (환산하지 못한 외화 금액은 원화 열에 쓰지 않고 오류)
+func convertResultsToExcelData(ocrResults []OCRResult, req *UploadRequest) ([]*ExcelData, error) {
 	var allExcelData []*ExcelData
 
 	for _, result := range ocrResults {
+		if isUnconvertedForeignAmount(result) {
+			return nil, fmt.Errorf("파일 '%s': %s %s 환율이 없어 원화 금액을 알 수 없습니다", result.FileName, result.Currency, result.OriginalAmount.Format())
+		}
+		vatSplit := resultVATSplit(result)
 		excelData := &ExcelData{
 			CASHCD:      result.Category,
 			RMKDC:       result.Remark,
 			TRNM:        result.Purpose,
-			SUPAM:       result.Amount,
-			VATAM:       "0",
+			SUPAM:       vatSplit.Supply,
+			VATAM:       vatSplit.VAT,
 			ATTRCD:      req.AttrCD,
 			ISSDT:       result.IssueDate,
 			PAYDT:       result.PayDate,
@@ -326,6 +782,7 @@ func convertResultsToExcelData(ocrResults []OCRResult, req *UploadRequest) []*Ex
 			DEPOSITORDC: req.DepositorDC,
 			DEPTCD:      req.DeptCD,
 			EMPCD:       req.EmpCD,
+			ORGAM:       formatCurrencyAudit(result),
 		}
 		allExcelData = append(allExcelData, excelData)
 	}
@@ -335,7 +792,17 @@ func convertResultsToExcelData(ocrResults []OCRResult, req *UploadRequest) []*Ex
 		return allExcelData[i].ISSDT < allExcelData[j].ISSDT
 	})
 
-	return allExcelData
+	return allExcelData, nil
+}
+
+// SSE 이벤트 1건 전송
+func writeSSEEvent(w *bufio.Writer, eventType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, data)
+	return w.Flush()
 }
 
 // Excel 파일 전송
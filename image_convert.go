@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "golang.org/x/image/webp"
+)
+
+// HEIC 변환 명령 실행 제한 시간
+const HEIC_CONVERT_TIMEOUT = 30 * time.Second
+
+// OCR에 보내기 전에 변환이 필요한 형식인지 확인
+func needsImageConversion(format string) bool {
+	return format == IMAGE_FORMAT_HEIC || format == IMAGE_FORMAT_WEBP
+}
+
+// HEIC/WebP를 OCR 지원 형식으로 변환 (변환 데이터와 형식 반환)
+// WebP는 Go로 디코딩해 무손실 PNG로, HEIC는 외부 변환 명령(HEIC_CONVERT_COMMAND)으로 JPEG로 변환합니다.
+func convertToOCRImage(filename string, data []byte, format string) ([]byte, string, error) {
+	startTime := time.Now()
+
+	var converted []byte
+	var err error
+	switch format {
+	case IMAGE_FORMAT_WEBP:
+		converted, err = convertWebPToPNG(data)
+	case IMAGE_FORMAT_HEIC:
+		converted, err = convertHEICToJPEG(data)
+	default:
+		return data, format, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	convertedFormat := detectImageFormat(converted)
+	if convertedFormat != IMAGE_FORMAT_JPEG && convertedFormat != IMAGE_FORMAT_PNG {
+		return nil, "", fmt.Errorf("%s 변환 결과가 JPEG/PNG가 아닙니다", strings.ToUpper(format))
+	}
+	log.Printf("🔄 파일 '%s': %s → %s 변환 (%s → %s, %v)", filename, format, convertedFormat,
+		formatByteSize(len(data)), formatByteSize(len(converted)), time.Since(startTime))
+	return converted, convertedFormat, nil
+}
+
+// WebP → PNG (무손실, 이후 전처리에서 JPEG로 재인코딩)
+func convertWebPToPNG(data []byte) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("WebP 디코딩 실패 (손상된 파일): %v", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("WebP 변환 실패: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// HEIC → JPEG (외부 명령: <명령> <입력.heic> <출력.jpg>)
+func convertHEICToJPEG(data []byte) ([]byte, error) {
+	command, err := findHEICConverter()
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir, err := os.MkdirTemp("", "heic-convert-")
+	if err != nil {
+		return nil, fmt.Errorf("HEIC 변환 임시 폴더 생성 실패: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	inputPath := filepath.Join(tempDir, "input.heic")
+	outputPath := filepath.Join(tempDir, "output.jpg")
+	if err := os.WriteFile(inputPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("HEIC 변환 임시 파일 쓰기 실패: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), HEIC_CONVERT_TIMEOUT)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, command, inputPath, outputPath).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("HEIC 변환 시간 초과 (%v)", HEIC_CONVERT_TIMEOUT)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("HEIC 변환 실패 (손상된 파일이거나 지원하지 않는 HEIC): %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	converted, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("HEIC 변환 결과를 읽을 수 없습니다: %v", err)
+	}
+	return converted, nil
+}
+
+// HEIC 변환 명령 경로 (HEIC_CONVERT_COMMAND, PATH에서 검색)
+func findHEICConverter() (string, error) {
+	command := getHEICConvertCommand()
+	if command == "" {
+		return "", fmt.Errorf("HEIC 변환이 비활성화되어 있습니다. JPEG로 변환해서 업로드해주세요")
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return "", fmt.Errorf("HEIC 변환 도구(%s)를 찾을 수 없습니다. JPEG로 변환해서 업로드해주세요", command)
+	}
+	return path, nil
+}
+
+// 업로드 원본 보관 (UPLOAD_ARCHIVE_DIR/날짜/해시_파일명, 실패해도 처리는 계속)
+func archiveUploadOriginal(imageFile ImageFile) {
+	filename, data := imageFile.Filename, imageFile.Data
+	if imageFile.OriginalData != nil {
+		data = imageFile.OriginalData
+	}
+
+	dir := getUploadArchiveDir()
+	if dir == "" {
+		return
+	}
+
+	dayDir := filepath.Join(dir, time.Now().Format("20060102"))
+	if err := os.MkdirAll(dayDir, 0o755); err != nil {
+		log.Printf("⚠️ 원본 보관 폴더 생성 실패 (%s): %v", dayDir, err)
+		return
+	}
+
+	// 같은 파일은 해시가 같으므로 한 번만 저장
+	path := filepath.Join(dayDir, imageSHA256(data)[:16]+"_"+filepath.Base(filename))
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0o644); err != nil {
+		log.Printf("⚠️ 원본 보관 실패 (%s): %v", filename, err)
+		return
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		log.Printf("⚠️ 원본 보관 실패 (%s): %v", filename, err)
+	}
+}
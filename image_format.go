@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// 파일 내용(매직 바이트)으로 판별한 형식 (OCR 요청 format 값)
+const (
+	IMAGE_FORMAT_JPEG = "jpg"
+	IMAGE_FORMAT_PNG  = "png"
+	IMAGE_FORMAT_PDF  = "pdf"
+	IMAGE_FORMAT_TIFF = "tiff"
+
+	// 업로드 시 JPEG/PNG로 변환하는 형식
+	IMAGE_FORMAT_HEIC = "heic"
+	IMAGE_FORMAT_WEBP = "webp"
+
+	// 판별은 되지만 OCR에 보낼 수 없는 형식
+	IMAGE_FORMAT_GIF = "gif"
+	IMAGE_FORMAT_BMP = "bmp"
+)
+
+// 이미지 크기 제한
+const (
+	MIN_IMAGE_DIMENSION = 32          // 짧은 변 최소 픽셀 (이보다 작으면 인식 불가)
+	MAX_IMAGE_PIXELS    = 100_000_000 // 최대 픽셀 수 (전처리 디코딩 메모리 보호)
+)
+
+// 문서 끝 표시 탐색 범위 (뒤에 붙는 부가 데이터 허용)
+const PDF_EOF_SEARCH_BYTES = 1024
+
+// 확장자별 예상 형식 (내용과 다르면 로그만 남기고 내용 기준으로 처리)
+var IMAGE_FORMAT_EXTENSIONS = map[string]string{
+	".jpg":  IMAGE_FORMAT_JPEG,
+	".jpeg": IMAGE_FORMAT_JPEG,
+	".png":  IMAGE_FORMAT_PNG,
+	".pdf":  IMAGE_FORMAT_PDF,
+	".tif":  IMAGE_FORMAT_TIFF,
+	".tiff": IMAGE_FORMAT_TIFF,
+	".heic": IMAGE_FORMAT_HEIC,
+	".heif": IMAGE_FORMAT_HEIC,
+	".webp": IMAGE_FORMAT_WEBP,
+}
+
+// DetectedImage 업로드 파일 판별 결과
+type DetectedImage struct {
+	Format string
+	Width  int // PDF/HEIC는 0
+	Height int // PDF/HEIC는 0
+}
+
+// UploadFileError 파일별 업로드 검증 오류
+type UploadFileError struct {
+	FileName string `json:"fileName"`
+	Error    string `json:"error"`
+}
+
+// UploadValidationError 업로드 파일 검증 실패 (실패한 파일 전체 목록)
+type UploadValidationError struct {
+	Files []UploadFileError
+}
+
+func (e *UploadValidationError) Error() string {
+	messages := make([]string, len(e.Files))
+	for i, file := range e.Files {
+		messages[i] = fmt.Sprintf("'%s': %s", file.FileName, file.Error)
+	}
+	return fmt.Sprintf("%d개 파일을 처리할 수 없습니다 - %s", len(e.Files), strings.Join(messages, "; "))
+}
+
+// 매직 바이트로 파일 형식 판별 (알 수 없으면 빈 문자열)
+func detectImageFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return IMAGE_FORMAT_JPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return IMAGE_FORMAT_PNG
+	case bytes.HasPrefix(data, []byte("II*\x00")), bytes.HasPrefix(data, []byte("MM\x00*")):
+		return IMAGE_FORMAT_TIFF
+	case bytes.Contains(data[:min(len(data), PDF_EOF_SEARCH_BYTES)], []byte("%PDF-")):
+		return IMAGE_FORMAT_PDF
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return IMAGE_FORMAT_WEBP
+	case len(data) >= 12 && string(data[4:8]) == "ftyp" && isHEIFBrand(string(data[8:12])):
+		return IMAGE_FORMAT_HEIC
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return IMAGE_FORMAT_GIF
+	case bytes.HasPrefix(data, []byte("BM")) && len(data) >= 26:
+		return IMAGE_FORMAT_BMP
+	}
+	return ""
+}
+
+// HEIF 계열 ftyp 브랜드 (아이폰 HEIC 등)
+func isHEIFBrand(brand string) bool {
+	switch brand {
+	case "heic", "heix", "heim", "heis", "hevc", "hevx", "mif1", "msf1", "avif":
+		return true
+	}
+	return false
+}
+
+// 업로드 파일 검증 (형식 판별, 잘림 확인, 이미지 크기 확인)
+func inspectImageFile(data []byte) (DetectedImage, error) {
+	if len(data) == 0 {
+		return DetectedImage{}, fmt.Errorf("빈 파일입니다")
+	}
+
+	format := detectImageFormat(data)
+	switch format {
+	case "":
+		return DetectedImage{}, fmt.Errorf("이미지 또는 PDF 파일이 아닙니다 (지원 형식: JPEG, PNG, PDF, TIFF, HEIC, WebP)")
+	case IMAGE_FORMAT_GIF, IMAGE_FORMAT_BMP:
+		return DetectedImage{}, fmt.Errorf("%s 형식은 지원하지 않습니다. JPEG 또는 PNG로 변환해서 업로드해주세요", strings.ToUpper(format))
+	}
+
+	detected := DetectedImage{Format: format}
+	if err := checkImageComplete(format, data); err != nil {
+		return detected, err
+	}
+	// HEIC는 Go로 헤더를 읽을 수 없으므로 변환 후 다시 검증
+	if format == IMAGE_FORMAT_PDF || format == IMAGE_FORMAT_HEIC {
+		return detected, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return detected, fmt.Errorf("%s 헤더를 읽을 수 없습니다 (손상된 파일): %v", strings.ToUpper(format), err)
+	}
+	detected.Width, detected.Height = config.Width, config.Height
+
+	if min(config.Width, config.Height) < MIN_IMAGE_DIMENSION {
+		return detected, fmt.Errorf("이미지가 너무 작습니다 (%dx%d, 최소 %dpx)", config.Width, config.Height, MIN_IMAGE_DIMENSION)
+	}
+	if config.Width*config.Height > MAX_IMAGE_PIXELS {
+		return detected, fmt.Errorf("이미지가 너무 큽니다 (%dx%d, 최대 %d만 픽셀)", config.Width, config.Height, MAX_IMAGE_PIXELS/10_000)
+	}
+	return detected, nil
+}
+
+// 파일 끝 표시로 업로드 중 잘린 파일 확인
+func checkImageComplete(format string, data []byte) error {
+	switch format {
+	case IMAGE_FORMAT_JPEG:
+		// EOI 마커 (뒤에 붙는 제조사 부가 데이터는 허용)
+		if bytes.LastIndex(data, []byte{0xFF, 0xD9}) < 2 {
+			return fmt.Errorf("JPEG 파일이 잘려 있습니다 (EOI 마커 없음)")
+		}
+	case IMAGE_FORMAT_PNG:
+		// IEND 청크 + CRC
+		if end := bytes.LastIndex(data, []byte("IEND")); end < 0 || end+8 > len(data) {
+			return fmt.Errorf("PNG 파일이 잘려 있습니다 (IEND 청크 없음)")
+		}
+	case IMAGE_FORMAT_PDF:
+		if !bytes.Contains(data[max(0, len(data)-PDF_EOF_SEARCH_BYTES):], []byte("%%EOF")) {
+			return fmt.Errorf("PDF 파일이 잘려 있습니다 (%%%%EOF 없음)")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"log"
+	"strings"
+	"time"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff"
+)
+
+// 자동 자르기 기준
+const (
+	AUTO_CROP_BRIGHT_RATIO = 0.5  // 가장 밝은 행/열 대비 밝은 픽셀 수가 이 비율 이상이면 영수증 영역
+	AUTO_CROP_MIN_AREA     = 0.2  // 잘라낸 영역이 원본의 이 비율 미만이면 자르지 않음 (오검출 방지)
+	AUTO_CROP_MAX_AREA     = 0.95 // 잘라낸 영역이 원본의 이 비율 이상이면 자를 필요 없음
+	AUTO_CROP_MARGIN       = 0.02 // 잘라낸 영역 바깥 여백 (긴 변 기준 비율)
+)
+
+// ImagePreprocessOptions 전처리 설정 (PREPROCESS_*)
+type ImagePreprocessOptions struct {
+	MaxDimension int // 긴 변 최대 픽셀 (0이면 축소 안 함)
+	Grayscale    bool
+	JPEGQuality  int
+	AutoCrop     bool
+}
+
+// ImagePreprocessResult 전처리 결과 (로그용 변경 내역 포함)
+type ImagePreprocessResult struct {
+	ImageFile ImageFile
+	Applied   []string // 적용한 단계 (orientation, resize, grayscale, crop)
+}
+
+// 환경변수 기준 전처리 설정
+func getImagePreprocessOptions() ImagePreprocessOptions {
+	return ImagePreprocessOptions{
+		MaxDimension: getPreprocessMaxDimension(),
+		Grayscale:    isPreprocessGrayscale(),
+		JPEGQuality:  getPreprocessJPEGQuality(),
+		AutoCrop:     isPreprocessAutoCrop(),
+	}
+}
+
+// OCR 전송 전 이미지 전처리 (EXIF 회전 → 축소 → 흑백 → 자동 자르기 → JPEG 재인코딩)
+// PDF 등 디코딩할 수 없는 형식이나 처리 후 더 커지는 경우에는 원본을 그대로 사용합니다.
+func preprocessImage(imageFile ImageFile, options ImagePreprocessOptions) ImagePreprocessResult {
+	unchanged := ImagePreprocessResult{ImageFile: imageFile}
+	if imageFile.Format == IMAGE_FORMAT_PDF {
+		return unchanged
+	}
+
+	startTime := time.Now()
+	img, format, err := image.Decode(bytes.NewReader(imageFile.Data))
+	if err != nil {
+		log.Printf("⚠️ 이미지 전처리 건너뜀 (%s): 디코딩 실패: %v", imageFile.Filename, err)
+		return unchanged
+	}
+
+	var applied []string
+	bounds := img.Bounds()
+
+	// 긴 변 기준 축소 (흑백 변환도 함께 수행)
+	width, height := bounds.Dx(), bounds.Dy()
+	if longest := max(width, height); options.MaxDimension > 0 && longest > options.MaxDimension {
+		width = max(1, width*options.MaxDimension/longest)
+		height = max(1, height*options.MaxDimension/longest)
+		applied = append(applied, "resize")
+	}
+	var canvas xdraw.Image
+	if options.Grayscale {
+		canvas = image.NewGray(image.Rect(0, 0, width, height))
+		applied = append(applied, "grayscale")
+	} else {
+		canvas = image.NewRGBA(image.Rect(0, 0, width, height))
+	}
+	if width == bounds.Dx() && height == bounds.Dy() {
+		xdraw.Draw(canvas, canvas.Bounds(), img, bounds.Min, xdraw.Src)
+	} else {
+		xdraw.BiLinear.Scale(canvas, canvas.Bounds(), img, bounds, xdraw.Src, nil)
+	}
+
+	// EXIF 방향 적용 (JPEG만 해당)
+	if orientation := jpegEXIFOrientation(imageFile.Data); orientation > 1 {
+		canvas = applyEXIFOrientation(canvas, orientation)
+		applied = append(applied, fmt.Sprintf("orientation(%d)", orientation))
+	}
+
+	// 영수증 영역 자동 자르기 (선택)
+	if options.AutoCrop {
+		if cropped, ok := autoCropReceipt(canvas); ok {
+			canvas = cropped
+			applied = append(applied, "crop")
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, canvas, &jpeg.Options{Quality: options.JPEGQuality}); err != nil {
+		log.Printf("⚠️ 이미지 전처리 건너뜀 (%s): JPEG 인코딩 실패: %v", imageFile.Filename, err)
+		return unchanged
+	}
+
+	// 회전/자르기가 필요 없고 용량도 줄지 않으면 원본 유지
+	if buf.Len() >= len(imageFile.Data) && !containsPrefix(applied, "orientation") && !containsString(applied, "crop") {
+		return unchanged
+	}
+
+	processed := imageFile
+	processed.Data = buf.Bytes()
+	processed.Format = IMAGE_FORMAT_JPEG
+	log.Printf("🖼️ 이미지 전처리 (%s): %s %dx%d %s → jpeg %dx%d %s [%s] (%v)",
+		imageFile.Filename, format, bounds.Dx(), bounds.Dy(), formatByteSize(len(imageFile.Data)),
+		canvas.Bounds().Dx(), canvas.Bounds().Dy(), formatByteSize(buf.Len()), strings.Join(applied, ", "), time.Since(startTime))
+	return ImagePreprocessResult{ImageFile: processed, Applied: applied}
+}
+
+// JPEG EXIF 방향 태그 (0x0112) 읽기 (없거나 JPEG가 아니면 1)
+func jpegEXIFOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// APP1(Exif) 세그먼트 탐색 (SOS 이전까지)
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if marker == 0xDA || length < 2 || offset+2+length > len(data) {
+			return 1
+		}
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		offset += 2 + length
+	}
+	return 1
+}
+
+// EXIF TIFF 구조의 IFD0에서 방향 값 읽기
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + 12*i
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// EXIF 방향(2~8)에 맞게 이미지 회전/반전
+func applyEXIFOrientation(src xdraw.Image, orientation int) xdraw.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// 5~8은 가로/세로가 바뀜
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	var dst xdraw.Image
+	if _, isGray := src.(*image.Gray); isGray {
+		dst = image.NewGray(image.Rect(0, 0, dstWidth, dstHeight))
+	} else {
+		dst = image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 좌우 반전
+				dx, dy = width-1-x, y
+			case 3: // 180도 회전
+				dx, dy = width-1-x, height-1-y
+			case 4: // 상하 반전
+				dx, dy = x, height-1-y
+			case 5: // 좌우 반전 후 270도 회전
+				dx, dy = y, x
+			case 6: // 시계 방향 90도 회전
+				dx, dy = height-1-y, x
+			case 7: // 좌우 반전 후 90도 회전
+				dx, dy = height-1-y, width-1-x
+			case 8: // 반시계 방향 90도 회전
+				dx, dy = y, width-1-x
+			default:
+				dx, dy = x, y
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// 밝은 영수증 영역 자동 자르기 (행/열별 밝은 픽셀 비율로 영역 판단)
+func autoCropReceipt(src xdraw.Image) (xdraw.Image, bool) {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 16 || height < 16 {
+		return src, false
+	}
+
+	// 평균 밝기보다 밝은 픽셀을 종이로 간주
+	luminance := make([]uint8, width*height)
+	total := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			value := color.GrayModel.Convert(src.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray).Y
+			luminance[y*width+x] = value
+			total += int(value)
+		}
+	}
+	threshold := uint8(total / len(luminance))
+
+	rowBright := make([]int, height)
+	colBright := make([]int, width)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if luminance[y*width+x] > threshold {
+				rowBright[y]++
+				colBright[x]++
+			}
+		}
+	}
+
+	top, bottom := brightSpan(rowBright)
+	left, right := brightSpan(colBright)
+	if top < 0 || left < 0 {
+		return src, false
+	}
+
+	margin := int(float64(max(width, height)) * AUTO_CROP_MARGIN)
+	crop := image.Rect(left-margin, top-margin, right+1+margin, bottom+1+margin).Intersect(image.Rect(0, 0, width, height))
+	area := float64(crop.Dx()*crop.Dy()) / float64(width*height)
+	if area < AUTO_CROP_MIN_AREA || area >= AUTO_CROP_MAX_AREA {
+		return src, false
+	}
+
+	var dst xdraw.Image
+	if _, isGray := src.(*image.Gray); isGray {
+		dst = image.NewGray(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	} else {
+		dst = image.NewRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	}
+	xdraw.Draw(dst, dst.Bounds(), src, bounds.Min.Add(crop.Min), xdraw.Src)
+	return dst, true
+}
+
+// 밝은 픽셀 수가 최댓값 대비 기준 이상인 첫/마지막 위치 (없으면 -1)
+func brightSpan(counts []int) (int, int) {
+	peak := 0
+	for _, count := range counts {
+		peak = max(peak, count)
+	}
+
+	first, last := -1, -1
+	if peak == 0 {
+		return first, last
+	}
+	for i, count := range counts {
+		if float64(count) >= float64(peak)*AUTO_CROP_BRIGHT_RATIO {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	return first, last
+}
+
+// 로그용 바이트 크기 표시 (예: 8.4MB, 512KB)
+func formatByteSize(size int) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1fMB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%dKB", size/1024)
+	}
+	return fmt.Sprintf("%dB", size)
+}
+
+func containsPrefix(values []string, prefix string) bool {
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
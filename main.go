@@ -6,6 +6,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/joho/godotenv"
 )
 
@@ -22,10 +23,36 @@ func main() {
 		BodyLimit: uploadLimitMB * 1024 * 1024, // MB를 바이트로 변환
 	})
 
-	// 미들웨어 설정
+	// 미들웨어 설정 (핸들러 panic은 500 응답으로 변환해 서버가 종료되지 않도록 함)
+	app.Use(recover.New(recover.Config{EnableStackTrace: true}))
 	app.Use(logger.New())
 	app.Use(cors.New())
 
+	// 카테고리 설정 검증 (잘못된 설정 파일이면 시작 중단)
+	if _, err := getCategoryCatalog(); err != nil {
+		log.Fatalf("카테고리 설정 로드 실패: %v", err)
+	}
+
+	// 카테고리 규칙 검증 (잘못된 규칙 파일이면 시작 중단)
+	if _, err := getCategoryRuleEngine(); err != nil {
+		log.Fatalf("카테고리 규칙 로드 실패: %v", err)
+	}
+
+	// 환율 파일 검증 (잘못된 환율 파일이면 시작 중단)
+	if _, err := getFXRateTable(); err != nil {
+		log.Fatalf("환율 로드 실패: %v", err)
+	}
+
+	// 날짜 패턴 검증 (정규식 오류나 샘플 불일치가 있으면 시작 중단)
+	if _, err := getDateTimePatternSet(); err != nil {
+		log.Fatalf("날짜 패턴 로드 실패: %v", err)
+	}
+
+	// OCR 제공자 설정 검증 (지원하지 않는 제공자/녹화 모드면 시작 중단)
+	if _, err := getOCRService(); err != nil {
+		log.Fatalf("OCR 서비스 초기화 실패: %v", err)
+	}
+
 	// 라우트 설정
 	setupRoutes(app)
 
@@ -33,6 +60,17 @@ func main() {
 	port := getServerPort()
 	log.Printf("서버가 %s 포트에서 시작됩니다...", port)
 	log.Printf("업로드 제한: %dMB, 최대 파일 수: %d개", uploadLimitMB, getMaxFiles())
+	log.Printf("OCR 제공자: %s (녹화 모드: %s)", getOCRProviderName(), getOCRRecordMode())
+	if _, err := findHEICConverter(); err != nil {
+		log.Printf("⚠️ HEIC 업로드 불가: %v", err)
+	}
+	if dir := getUploadArchiveDir(); dir != "" {
+		log.Printf("업로드 원본 보관: %s", dir)
+	}
+	if isPreprocessEnabled() {
+		options := getImagePreprocessOptions()
+		log.Printf("이미지 전처리: 최대 %dpx, 흑백 %t, JPEG 품질 %d, 자동 자르기 %t", options.MaxDimension, options.Grayscale, options.JPEGQuality, options.AutoCrop)
+	}
 	log.Printf("2단계 OCR 플로우:")
 	log.Printf("  1단계: POST /api/process-ocr (OCR 처리)")
 	log.Printf("  2단계: POST /api/download-excel (Excel 다운로드)")
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// 내장 Mock OCR 엔드포인트 경로
+const MOCK_OCR_PATH = "/mock-ocr/infer"
+
+// 전처리 전 원본 이미지 해시 헤더 (전송된 이미지가 전처리로 바뀌어도 sha256 fixture 매칭)
+const MOCK_OCR_IMAGE_HASH_HEADER = "X-Mock-OCR-Image-SHA256"
+
+// 기본 fixture 키 (일치하는 fixture가 없을 때 사용)
+const MOCK_OCR_DEFAULT_FIXTURE = "default"
+
+// MockOCRFixture Mock OCR 응답 정의 (fixture 파일 1개 = 영수증 1건)
+type MockOCRFixture struct {
+	Filename    string             `json:"filename"`    // 매칭할 업로드 파일명 (선택)
+	SHA256      string             `json:"sha256"`      // 매칭할 이미지 해시 (선택)
+	InferResult string             `json:"inferResult"` // 기본값: SUCCESS
+	Message     string             `json:"message"`
+	Fields      map[string]string  `json:"fields"`     // 사용처, 사용액, 공급가, 부가세, 사용일
+	Confidence  map[string]float64 `json:"confidence"` // 필드별 신뢰도 (기본값: 1.0)
+	HTTPStatus  int                `json:"httpStatus"` // 오류 응답 시뮬레이션 (예: 429, 503)
+}
+
+// MockOCRServer CLOVA 템플릿 OCR 프로토콜을 흉내내는 내장 서버
+type MockOCRServer struct {
+	byHash     map[string]*MockOCRFixture
+	byFilename map[string]*MockOCRFixture
+	byKey      map[string]*MockOCRFixture
+}
+
+// fixture 디렉터리에서 Mock OCR 서버 생성
+func NewMockOCRServer(fixtureDir string) (*MockOCRServer, error) {
+	server := &MockOCRServer{
+		byHash:     make(map[string]*MockOCRFixture),
+		byFilename: make(map[string]*MockOCRFixture),
+		byKey:      make(map[string]*MockOCRFixture),
+	}
+
+	paths, err := filepath.Glob(filepath.Join(fixtureDir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("fixture 경로 탐색 실패: %v", err)
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("fixture '%s' 읽기 실패: %v", path, err)
+		}
+
+		var fixture MockOCRFixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("fixture '%s' 파싱 실패: %v", path, err)
+		}
+		if fixture.InferResult == "" {
+			fixture.InferResult = "SUCCESS"
+		}
+		if fixture.Message == "" {
+			fixture.Message = fixture.InferResult
+		}
+
+		// 파일명(확장자 제외)을 fixture 키로 사용
+		key := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		server.byKey[strings.ToLower(key)] = &fixture
+		if fixture.SHA256 != "" {
+			server.byHash[strings.ToLower(fixture.SHA256)] = &fixture
+		}
+		if fixture.Filename != "" {
+			server.byFilename[strings.ToLower(fixture.Filename)] = &fixture
+		}
+	}
+
+	log.Printf("Mock OCR fixture 로드 완료: %d개 (%s)", len(server.byKey), fixtureDir)
+	return server, nil
+}
+
+// Mock OCR 요청 처리 (OCRRequest → OCRResponse)
+func (m *MockOCRServer) handleInfer(c *fiber.Ctx) error {
+	var request OCRRequest
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"code":    "0011",
+			"message": "Request body invalid: " + err.Error(),
+		})
+	}
+
+	if len(request.Images) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"code":    "0011",
+			"message": "Request images empty",
+		})
+	}
+
+	response := OCRResponse{
+		Version:   request.Version,
+		RequestID: request.RequestID,
+		Timestamp: time.Now().UnixMilli(),
+	}
+
+	for _, image := range request.Images {
+		imageData, err := base64.StdEncoding.DecodeString(image.Data)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"code":    "0011",
+				"message": "Image data invalid: " + err.Error(),
+			})
+		}
+
+		imageHash := imageSHA256(imageData)
+		if sourceHash := c.Get(MOCK_OCR_IMAGE_HASH_HEADER); len(sourceHash) == 64 && len(request.Images) == 1 {
+			imageHash = strings.ToLower(sourceHash)
+		}
+		fixture, matchedBy := m.findFixture(imageHash, image.Name)
+
+		log.Printf("🧪 Mock OCR 응답: %s (매칭: %s, 해시: %s)", image.Name, matchedBy, imageHash[:12])
+		if fixture.HTTPStatus >= 400 {
+			return c.Status(fixture.HTTPStatus).JSON(fiber.Map{
+				"code":    strconv.Itoa(fixture.HTTPStatus),
+				"message": fixture.Message,
+			})
+		}
+		response.Images = append(response.Images, fixture.toImageResult(image.Name))
+	}
+
+	return c.JSON(response)
+}
+
+// 이미지 해시 → 파일명 → 파일명 키 → 기본 fixture 순서로 조회
+func (m *MockOCRServer) findFixture(imageHash, filename string) (*MockOCRFixture, string) {
+	if fixture, exists := m.byHash[imageHash]; exists {
+		return fixture, "sha256"
+	}
+
+	lowerName := strings.ToLower(filepath.Base(filename))
+	if fixture, exists := m.byFilename[lowerName]; exists {
+		return fixture, "filename"
+	}
+
+	key := strings.TrimSuffix(lowerName, filepath.Ext(lowerName))
+	if fixture, exists := m.byKey[key]; exists {
+		return fixture, "key"
+	}
+
+	if fixture, exists := m.byKey[MOCK_OCR_DEFAULT_FIXTURE]; exists {
+		return fixture, MOCK_OCR_DEFAULT_FIXTURE
+	}
+
+	return &MockOCRFixture{
+		InferResult: "FAILURE",
+		Message:     "No matching mock fixture",
+	}, "none"
+}
+
+// fixture를 OCR 이미지 결과로 변환
+func (f *MockOCRFixture) toImageResult(imageName string) OCRImageResult {
+	result := OCRImageResult{
+		UID:              uuid.New().String(),
+		Name:             imageName,
+		InferResult:      f.InferResult,
+		Message:          f.Message,
+		MatchedTemplate:  MatchedTemplate{ID: 0, Name: "mock-receipt"},
+		ValidationResult: ValidationResult{Result: "NO_REQUESTED"},
+	}
+
+	// 필드 순서를 고정해 응답을 재현 가능하게 유지
+	names := make([]string, 0, len(f.Fields))
+	for name := range f.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		confidence, exists := f.Confidence[name]
+		if !exists {
+			confidence = 1.0
+		}
+		result.Fields = append(result.Fields, Field{
+			Name:            name,
+			ValueType:       "ALL",
+			InferText:       f.Fields[name],
+			InferConfidence: confidence,
+		})
+	}
+
+	return result
+}
@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 // OCR 요청/응답 구조체
 type OCRRequest struct {
 	Version   string     `json:"version"`
@@ -73,11 +75,18 @@ type UploadRequest struct {
 	EmpCD       string `form:"emp_cd"`
 	BankCD      string `form:"bank_cd"`
 	BANB        string `form:"ba_nb"`
+
+	// 청구 기간 (선택, YYYY-MM-DD) - 연도가 없는 사용일 추론 기준
+	ClaimPeriodStart string `form:"claim_period_start"`
+	ClaimPeriodEnd   string `form:"claim_period_end"`
 }
 
 type ExcelDownloadRequest struct {
 	UploadRequest
-	ExcelData string `form:"excel_data"`
+	ExcelData       string `form:"excel_data"`
+	AllowDuplicates bool   `form:"allow_duplicates"` // 중복 의심 항목 포함 내보내기 허용
+	AllowUnreviewed bool   `form:"allow_unreviewed"` // 검토 대기 항목 포함 내보내기 허용
+	OverrideReason  string `form:"override_reason"`  // error 수준 정책 위반 내보내기 사유
 }
 
 // Excel 데이터 구조체
@@ -85,8 +94,8 @@ type ExcelData struct {
 	CASHCD      string
 	RMKDC       string
 	TRNM        string
-	SUPAM       string
-	VATAM       string
+	SUPAM       Money
+	VATAM       Money
 	ATTRCD      string
 	ISSDT       string
 	PAYDT       string
@@ -95,17 +104,28 @@ type ExcelData struct {
 	DEPOSITORDC string
 	DEPTCD      string
 	EMPCD       string
+	ORGAM       string // 외화 원금액/환율 (감사용)
 }
 
 // 이미지 파일 정보 구조체
 type ImageFile struct {
-	Data     []byte
-	Filename string
+	Data       []byte
+	Filename   string // 다중 페이지 문서의 페이지면 페이지 파일명 (예: receipts_p2.pdf)
+	SourceFile string // 업로드 원본 파일명
+	Page       int    // 원본 문서의 페이지 번호 (1부터)
+	PageCount  int    // 원본 문서의 전체 페이지 수
+	Format     string // 파일 내용으로 판별한 OCR 전송 형식 (전처리 후 jpg)
+	SHA256     string // 전처리 전 이미지 해시 (캐시/녹화/Mock fixture 키, 비어 있으면 Data 기준)
+
+	// HEIC/WebP 변환 전 업로드 원본 (변환하지 않았으면 비어 있음)
+	OriginalData   []byte
+	OriginalFormat string
 }
 
 // 카테고리와 추가 정보가 포함된 이미지 파일 구조체
 type ImageFileWithCategory struct {
 	ImageFile
+	UploadIndex     int // 업로드 파일 순번 (메타데이터 키)
 	Category        string
 	Remarks         string
 	BusinessContent string // 국내출장 전용
@@ -114,37 +134,104 @@ type ImageFileWithCategory struct {
 
 // OCR 결과 구조체
 type SingleImageOCRResult struct {
-	ImageIndex int
-	ImageName  string
-	Response   *OCRImageResult
-	Error      error
+	ImageIndex    int
+	ImageName     string
+	Response      *OCRImageResult
+	Error         error
+	ErrorCategory OCRErrorCategory // 실패 분류 (성공 시 빈 값)
+	CacheHit      bool             // OCR 결과 캐시 사용 여부
+	ImageHash     string           // 이미지 SHA-256
 }
 
 // 카테고리가 포함된 OCR 결과 구조체
 type SingleImageOCRResultWithCategory struct {
 	SingleImageOCRResult
 	Category        string
+	CategoryRule    *CategoryRuleMatch // 카테고리 결정에 적용된 규칙 (없으면 nil)
 	Remarks         string
 	BusinessContent string // 국내출장 전용
 	Purpose         string // 국내출장 전용
 }
 
+// OCR 진행 이벤트 종류
+const (
+	OCR_EVENT_STARTED           = "started"
+	OCR_EVENT_COMPLETED         = "completed"
+	OCR_EVENT_FAILED            = "failed"
+	OCR_EVENT_CATEGORY_ADJUSTED = "category_adjusted"
+)
+
+// 이미지별 OCR 진행 이벤트
+type OCRProgressEvent struct {
+	Type             string
+	Index            int
+	FileName         string
+	Result           *SingleImageOCRResultWithCategory // completed/failed 이벤트에서만 설정
+	OriginalCategory string                            // category_adjusted 이벤트에서만 설정
+	Category         string                            // category_adjusted 이벤트에서만 설정
+	CategoryRule     *CategoryRuleMatch                // category_adjusted 이벤트에서만 설정
+}
+
+// OCR 진행 콜백 (작업 풀 고루틴에서 동시에 호출될 수 있음)
+type OCRProgressFunc func(event OCRProgressEvent)
+
 // 프론트엔드 응답 구조체
 type OCRProcessResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    []OCRResult `json:"data"`
+	Success   bool         `json:"success"`
+	Message   string       `json:"message"`
+	Error     string       `json:"error,omitempty"`
+	Total     int          `json:"total"`
+	Succeeded int          `json:"succeeded"`
+	CacheHits int          `json:"cacheHits"` // 캐시로 처리된 파일 수
+	Data      []OCRResult  `json:"data"`
+	Failures  []OCRFailure `json:"failures"`
+}
+
+// 처리에 실패한 파일 정보
+type OCRFailure struct {
+	FileName        string           `json:"fileName"`
+	ErrorClass      OCRErrorCategory `json:"errorClass"`
+	Retryable       bool             `json:"retryable"`
+	InferResult     string           `json:"inferResult,omitempty"`
+	InferMessage    string           `json:"inferMessage,omitempty"`
+	Error           string           `json:"error"`
+	SuggestedAction string           `json:"suggestedAction"`
 }
 
 type OCRResult struct {
-	FileName          string `json:"fileName"`
-	Category          string `json:"category"`
-	Remark            string `json:"remark"`
-	Purpose           string `json:"purpose"`
-	Amount            string `json:"amount"`
-	IssueDate         string `json:"issueDate"`
-	PayDate           string `json:"payDate"`
-	OriginalIssueDate string `json:"originalIssueDate"`         // 원본 사용일 (시간 정보 포함)
-	BusinessContent   string `json:"businessContent,omitempty"` // 국내출장 전용
-	BusinessPurpose   string `json:"businessPurpose,omitempty"` // 국내출장 전용
+	FileName          string             `json:"fileName"`
+	SourceFile        string             `json:"sourceFile,omitempty"` // 다중 페이지 문서의 원본 파일명
+	Page              int                `json:"page,omitempty"`       // 원본 문서의 페이지 번호
+	PageCount         int                `json:"pageCount,omitempty"`  // 원본 문서의 전체 페이지 수
+	Category          string             `json:"category"`
+	CategoryRule      *CategoryRuleMatch `json:"categoryRule,omitempty"` // 카테고리 결정에 적용된 규칙
+	Remark            string             `json:"remark"`
+	Purpose           string             `json:"purpose"`
+	Amount            Money              `json:"amount"` // 사용액 (JSON 문자열, 환불은 음수)
+	IssueDate         string             `json:"issueDate"`
+	PayDate           string             `json:"payDate"`
+	OriginalIssueDate string             `json:"originalIssueDate"`          // 원본 사용일 (시간 정보 포함)
+	IssueDateParse    *DateTimeParseInfo `json:"issueDateParse,omitempty"`   // 사용일 파싱 근거 (추정 여부)
+	BusinessContent   string             `json:"businessContent,omitempty"`  // 국내출장 전용
+	BusinessPurpose   string             `json:"businessPurpose,omitempty"`  // 국내출장 전용
+	CacheHit          bool               `json:"cacheHit"`                   // OCR 결과 캐시 사용 여부
+	ImageHash         string             `json:"imageHash,omitempty"`        // 원본 이미지 SHA-256 (중복 확인용)
+	BatchID           string             `json:"batchId,omitempty"`          // OCR 업로드 배치 ID (재다운로드 시 자기 이력 제외)
+	UploadedAt        *time.Time         `json:"uploadedAt,omitempty"`       // 업로드 일시 (내보낼 때 사용일 추론 기준)
+	ClaimPeriodStart  string             `json:"claimPeriodStart,omitempty"` // 업로드 시 청구 기간 시작일
+	ClaimPeriodEnd    string             `json:"claimPeriodEnd,omitempty"`   // 업로드 시 청구 기간 종료일
+	Duplicate         *DuplicateInfo     `json:"duplicate,omitempty"`        // 중복 의심 정보
+	SupplyAmount      Money              `json:"supplyAmount"`               // 공급가액 (SUP_AM)
+	VATAmount         Money              `json:"vatAmount"`                  // 부가세 (VAT_AM)
+	TaxExempt         bool               `json:"taxExempt,omitempty"`        // 면세 여부
+	AdditionalNames   string             `json:"additionalNames,omitempty"`  // 추가 이름 (1인당 한도 계산용)
+	Violations        []PolicyViolation  `json:"violations,omitempty"`       // 경비 정책 위반
+	Currency          string             `json:"currency,omitempty"`         // 외화 영수증 통화 (원화는 빈 값)
+	OriginalAmount    Money              `json:"originalAmount,omitempty"`   // 외화 원금액
+	FXRate            string             `json:"fxRate,omitempty"`           // 적용 환율 (단위당 원화)
+	FXRateDate        string             `json:"fxRateDate,omitempty"`       // 환율 고시일 (YYYYMMDD)
+	FieldConfidence   map[string]float64 `json:"fieldConfidence,omitempty"`  // 필드별 OCR 신뢰도
+	ReviewFields      []string           `json:"reviewFields,omitempty"`     // 신뢰도 기준 미만 필드 (검토 필요)
+	ReviewID          string             `json:"reviewId,omitempty"`         // 검토 대기열 항목 ID
+	ReviewStatus      string             `json:"reviewStatus,omitempty"`     // 검토 상태 (pending, confirmed, corrected)
 }
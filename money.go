@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// 금액 최소 단위 (1원 = 100, 소수점 둘째 자리까지 보존)
+const MONEY_SCALE = 100
+
+// 금액 앞뒤에 붙을 수 있는 통화 기호/단위와 공백
+const MONEY_DECORATION_CHARS = "₩$€¥ KRWkrw원"
+
+// Money 금액 (최소 단위 정수, 부동소수점 오차 없음)
+type Money int64
+
+var errMoneyNotFound = errors.New("금액을 찾을 수 없습니다")
+
+// 원 단위 정수를 금액으로 변환
+func MoneyFromWon(won int64) Money {
+	return Money(won * MONEY_SCALE)
+}
+
+// 금액 문자열 파싱 (예: "₩12,300.50", "-5,000원", "5,000-", "(5,000원)", "１２,０００원")
+//
+// 공백으로 나뉜 여러 토큰 중에서는 금액 토큰(통화 기호/단위와 부호만 붙은 숫자)만 사용하고
+// ("A-1 5,000" → 5,000), 그런 토큰이 없으면 첫 번째 숫자 묶음을 사용합니다.
+// 마지막 콤마 뒤가 1~2자리이거나 유로 금액이면 콤마를 소수점, 점을 천 단위 구분 기호로 보고
+// ("12,50 €", "1.234,56 €"), 그 밖의 콤마는 천 단위 구분 기호로만 인정합니다 ("1,2345"는 오류).
+// 콤마가 없고 점 뒤에 숫자가 정확히 3자리씩 이어지면 ("12.300") OCR이 콤마를 점으로 읽은
+// 것으로 보고 천 단위 구분 기호로 처리합니다. 부호는 앞의 '-', 뒤의 '-' ("5,000원-"),
+// 회계식 괄호로 표시합니다.
+func ParseMoney(text string) (Money, error) {
+	text = normalizeMoneyText(text)
+	if strings.TrimSpace(text) == "" {
+		return 0, nil
+	}
+	euro := detectAmountCurrency(text) == CURRENCY_EUR
+	text = moneyAmountToken(text)
+
+	runes := []rune(text)
+	start := -1
+	for i, r := range runes {
+		if r >= '0' && r <= '9' {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return 0, fmt.Errorf("%w: %q", errMoneyNotFound, text)
+	}
+
+	end := start
+	for end < len(runes) {
+		r := runes[end]
+		isSeparator := (r == ',' || r == '.') && end+1 < len(runes) && runes[end+1] >= '0' && runes[end+1] <= '9'
+		if (r < '0' || r > '9') && !isSeparator {
+			break
+		}
+		end++
+	}
+	number := string(runes[start:end])
+
+	// 부호: 숫자 앞뒤의 '-' 또는 회계식 괄호 표기 (통화 기호/단위는 건너뜀)
+	prefix := strings.TrimSpace(string(runes[:start]))
+	suffix := strings.TrimSpace(string(runes[end:]))
+	prefixSign := strings.TrimRight(prefix, MONEY_DECORATION_CHARS)
+	suffixSign := strings.TrimLeft(suffix, MONEY_DECORATION_CHARS)
+	trailingMinus := strings.HasPrefix(suffixSign, "-") && !startsWithDigit(suffixSign[1:])
+	negative := strings.HasSuffix(prefixSign, "-") ||
+		strings.HasPrefix(prefix, "-") ||
+		trailingMinus ||
+		(strings.HasSuffix(prefixSign, "(") && strings.HasPrefix(suffixSign, ")"))
+
+	intPart, fracPart, err := splitMoneyNumber(number, euro)
+	if err != nil {
+		return 0, fmt.Errorf("금액 형식 오류 %q: %v", text, err)
+	}
+
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || units > math.MaxInt64/MONEY_SCALE {
+		return 0, fmt.Errorf("금액 범위 초과: %q", text)
+	}
+	amount := units * MONEY_SCALE
+
+	// 소수부는 최소 단위로 반올림
+	if fracPart != "" {
+		for len(fracPart) < 3 {
+			fracPart += "0"
+		}
+		fraction, _ := strconv.ParseInt(fracPart[:3], 10, 64)
+		amount += (fraction + 5) / 10
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+// 여러 토큰 중 금액 토큰과 앞뒤의 부호/통화 토큰만 추출 (금액 토큰이 없으면 원문 유지)
+func moneyAmountToken(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return text
+	}
+	for i, field := range fields {
+		if !isMoneyToken(field) {
+			continue
+		}
+		first, last := i, i
+		for first > 0 && isMoneyDecoration(fields[first-1]) {
+			first--
+		}
+		for last < len(fields)-1 && isMoneyDecoration(fields[last+1]) {
+			last++
+		}
+		return strings.Join(fields[first:last+1], " ")
+	}
+	return text
+}
+
+// 통화 기호/단위, 부호, 괄호를 제외하면 숫자와 구분 기호만 남는 토큰인지 확인
+func isMoneyToken(token string) bool {
+	core := strings.Trim(token, MONEY_DECORATION_CHARS+"()-+")
+	if !startsWithDigit(core) {
+		return false
+	}
+	for _, r := range core {
+		if (r < '0' || r > '9') && r != ',' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// 통화 기호/단위, 부호, 괄호로만 이루어진 토큰인지 확인 (예: "₩", "-", "원)")
+func isMoneyDecoration(token string) bool {
+	return strings.Trim(token, MONEY_DECORATION_CHARS+"()-+") == ""
+}
+
+func startsWithDigit(text string) bool {
+	return text != "" && text[0] >= '0' && text[0] <= '9'
+}
+
+// 숫자 묶음을 정수부/소수부로 분리 (decimalComma면 콤마 뒤 자릿수와 관계없이 소수점 콤마 허용)
+func splitMoneyNumber(number string, decimalComma bool) (string, string, error) {
+	dotCount := strings.Count(number, ".")
+	hasComma := strings.Contains(number, ",")
+
+	// 소수점 콤마 (예: "12,50", "1.234,56"), 점이 있으면 천 단위 구분 기호
+	if lastComma := strings.LastIndex(number, ","); lastComma > strings.LastIndex(number, ".") {
+		intPart, fracPart := number[:lastComma], number[lastComma+1:]
+		if len(fracPart) <= 2 || decimalComma {
+			if strings.Contains(intPart, ",") {
+				return "", "", fmt.Errorf("소수점(콤마)이 여러 개입니다")
+			}
+			if dotCount > 0 && !isThousandsGrouped(strings.Split(intPart, ".")) {
+				return "", "", fmt.Errorf("천 단위 구분 기호 위치가 잘못되었습니다")
+			}
+			return strings.ReplaceAll(intPart, ".", ""), fracPart, nil
+		}
+	}
+
+	if dotCount > 0 && !hasComma && isThousandsGrouped(strings.Split(number, ".")) {
+		return strings.ReplaceAll(number, ".", ""), "", nil
+	}
+	if dotCount > 1 {
+		return "", "", fmt.Errorf("소수점이 여러 개입니다")
+	}
+
+	intPart, fracPart, _ := strings.Cut(number, ".")
+	if strings.Contains(fracPart, ",") {
+		return "", "", fmt.Errorf("소수부에 콤마가 있습니다")
+	}
+	if hasComma && !isThousandsGrouped(strings.Split(intPart, ",")) {
+		return "", "", fmt.Errorf("천 단위 구분 기호 위치가 잘못되었습니다")
+	}
+	return strings.ReplaceAll(intPart, ",", ""), fracPart, nil
+}
+
+// 첫 묶음 1~3자리, 이후 묶음이 모두 3자리인지 확인
+func isThousandsGrouped(groups []string) bool {
+	if len(groups) < 2 || len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return false
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// 전각 숫자/기호를 반각으로 변환
+func normalizeMoneyText(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '０' && r <= '９':
+			return '0' + (r - '０')
+		case r == '，':
+			return ','
+		case r == '．':
+			return '.'
+		case r == '－', r == '−':
+			return '-'
+		case r == '（':
+			return '('
+		case r == '）':
+			return ')'
+		case r == '￦':
+			return '₩'
+		}
+		return r
+	}, text)
+}
+
+// 원 단위 정수 (소수부 반올림)
+func (m Money) Won() int64 {
+	if m < 0 {
+		return -((-int64(m) + MONEY_SCALE/2) / MONEY_SCALE)
+	}
+	return (int64(m) + MONEY_SCALE/2) / MONEY_SCALE
+}
+
+// 비율 적용 후 원 단위로 반올림 (예: 공급가액 = 합계 × 10/11)
+func (m Money) Ratio(numerator, denominator int64) Money {
+	scaled := int64(m) * numerator
+	unit := denominator * MONEY_SCALE
+	if scaled < 0 {
+		return -Money((-scaled + unit/2) / unit * MONEY_SCALE)
+	}
+	return Money((scaled + unit/2) / unit * MONEY_SCALE)
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+// 구분 기호 없는 문자열 (Excel 입력용, 예: "12300", "12300.50", "-5000")
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	text := sign + strconv.FormatInt(value/MONEY_SCALE, 10)
+	if fraction := value % MONEY_SCALE; fraction != 0 {
+		text += fmt.Sprintf(".%02d", fraction)
+	}
+	return text
+}
+
+// 천 단위 구분 기호 적용 (예: "12,300", "-5,000")
+func (m Money) Format() string {
+	text := m.String()
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	intPart, fracPart, hasFraction := strings.Cut(text, ".")
+	for i := len(intPart) - 3; i > 0; i -= 3 {
+		intPart = intPart[:i] + "," + intPart[i:]
+	}
+	if hasFraction {
+		return sign + intPart + "." + fracPart
+	}
+	return sign + intPart
+}
+
+// JSON에서는 기존과 같이 문자열로 직렬화
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// 문자열("12,300원")과 숫자(12300) 모두 허용
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("금액은 문자열 또는 숫자여야 합니다: %s", data)
+		}
+		text = number.String()
+	}
+	amount, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
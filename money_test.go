@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string // Money.String() 결과
+	}{
+		// 원화
+		{"12,300원", "12300"},
+		{"₩12,300.50", "12300.50"},
+		{"1,000,000", "1000000"},
+		{"12.300", "12300"},
+		{"１２,０００원", "12000"},
+		{"KRW5,000", "5000"},
+		{"합계:5,000원", "5000"},
+		{"", "0"},
+
+		// 부호
+		{"-5,000원", "-5000"},
+		{"- 5,000", "-5000"},
+		{"5,000-", "-5000"},
+		{"9,500원-", "-9500"},
+		{"9,500원 -", "-9500"},
+		{"(5,000)", "-5000"},
+		{"(5,000원)", "-5000"},
+		{"(₩5,000)", "-5000"},
+		{"5,000-6,000", "5000"},
+
+		// 금액 토큰만 사용
+		{"A-1 5,000", "5000"},
+		{"USD 42.50", "42.50"},
+
+		// 소수점 콤마 (유로 표기)
+		{"12,50 €", "12.50"},
+		{"€12,50", "12.50"},
+		{"1.234,56 €", "1234.56"},
+		{"EUR 1.234,5", "1234.50"},
+		{"€1,234", "1.23"},
+		{"(12,50 €)", "-12.50"},
+		{"12,30", "12.30"},
+		{"€12.50", "12.50"},
+	}
+	for _, test := range tests {
+		got, err := ParseMoney(test.input)
+		if err != nil {
+			t.Errorf("ParseMoney(%q) 오류: %v", test.input, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("ParseMoney(%q) = %s, 기대값 %s", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseMoneyInvalid(t *testing.T) {
+	for _, input := range []string{
+		"1,2345",     // 천 단위 구분 기호 위치 오류
+		"1,23,45",    // 소수점 콤마가 여러 개
+		"12.34.5",    // 소수점이 여러 개
+		"1.23,45 €",  // 점 구분 기호 위치 오류
+		"12,345.6,7", // 소수부에 콤마
+		"금액 없음",
+	} {
+		if got, err := ParseMoney(input); err == nil {
+			t.Errorf("ParseMoney(%q) = %s, 오류를 기대함", input, got)
+		}
+	}
+}
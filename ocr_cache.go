@@ -0,0 +1,161 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OCRResultCache 이미지 SHA-256 기반 OCR 결과 캐시 (TTL + 최대 개수 LRU)
+type OCRResultCache struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // 앞쪽이 최근 사용
+	ttl        time.Duration
+	maxEntries int
+
+	hits   int64
+	misses int64
+}
+
+type ocrCacheEntry struct {
+	hash      string
+	result    *OCRImageResult
+	expiresAt time.Time
+}
+
+// OCRCacheStats 캐시 통계
+type OCRCacheStats struct {
+	Enabled    bool  `json:"enabled"`
+	Entries    int   `json:"entries"`
+	MaxEntries int   `json:"maxEntries"`
+	TTLMinutes int   `json:"ttlMinutes"`
+	Hits       int64 `json:"hits"`
+	Misses     int64 `json:"misses"`
+}
+
+var (
+	ocrResultCache     *OCRResultCache
+	ocrResultCacheOnce sync.Once
+)
+
+// 전역 OCR 결과 캐시 반환 (비활성화 시 nil)
+func getOCRResultCache() *OCRResultCache {
+	if !isOCRCacheEnabled() {
+		return nil
+	}
+	ocrResultCacheOnce.Do(func() {
+		ocrResultCache = NewOCRResultCache(time.Duration(getOCRCacheTTLMinutes())*time.Minute, getOCRCacheMaxEntries())
+	})
+	return ocrResultCache
+}
+
+// OCR 결과 캐시 생성자
+func NewOCRResultCache(ttl time.Duration, maxEntries int) *OCRResultCache {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &OCRResultCache{
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+// 캐시 조회 (만료된 항목은 삭제 후 미스 처리)
+func (c *OCRResultCache) Get(hash string) (*OCRImageResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[hash]
+	if exists {
+		entry := element.Value.(*ocrCacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			c.lru.MoveToFront(element)
+			atomic.AddInt64(&c.hits, 1)
+			return copyOCRImageResult(entry.result), true
+		}
+		c.removeLocked(element)
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+	return nil, false
+}
+
+// 통계/사용 순서에 영향 없이 조회 (내보내기 시 서버 기준 값 확인용)
+func (c *OCRResultCache) Peek(hash string) (*OCRImageResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[hash]
+	if !exists {
+		return nil, false
+	}
+	entry := element.Value.(*ocrCacheEntry)
+	if !time.Now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return copyOCRImageResult(entry.result), true
+}
+
+// 캐시 저장 (최대 개수 초과 시 가장 오래 사용하지 않은 항목 제거)
+func (c *OCRResultCache) Put(hash string, result *OCRImageResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &ocrCacheEntry{
+		hash:      hash,
+		result:    copyOCRImageResult(result),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+
+	if element, exists := c.entries[hash]; exists {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[hash] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		c.removeLocked(c.lru.Back())
+	}
+}
+
+// 캐시 통계 조회
+func (c *OCRResultCache) Stats() OCRCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return OCRCacheStats{
+		Enabled:    true,
+		Entries:    c.lru.Len(),
+		MaxEntries: c.maxEntries,
+		TTLMinutes: int(c.ttl / time.Minute),
+		Hits:       atomic.LoadInt64(&c.hits),
+		Misses:     atomic.LoadInt64(&c.misses),
+	}
+}
+
+// 전역 캐시 통계 조회 (비활성화 시 enabled=false)
+func getOCRCacheStats() OCRCacheStats {
+	cache := getOCRResultCache()
+	if cache == nil {
+		return OCRCacheStats{Enabled: false}
+	}
+	return cache.Stats()
+}
+
+func (c *OCRResultCache) removeLocked(element *list.Element) {
+	entry := element.Value.(*ocrCacheEntry)
+	delete(c.entries, entry.hash)
+	c.lru.Remove(element)
+}
+
+// 캐시된 결과가 호출 측 수정에 영향받지 않도록 복사
+func copyOCRImageResult(result *OCRImageResult) *OCRImageResult {
+	copied := *result
+	copied.Fields = append([]Field(nil), result.Fields...)
+	return &copied
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// OCRErrorCategory OCR 실패 분류 (UI에서 "나중에 재시도"와 "잘못된 이미지" 구분용)
+type OCRErrorCategory string
+
+const (
+	OCR_ERROR_NETWORK          OCRErrorCategory = "network"          // 연결 실패/타임아웃 (재시도)
+	OCR_ERROR_RATE_LIMITED     OCRErrorCategory = "rate_limited"     // 429 (재시도)
+	OCR_ERROR_SERVER           OCRErrorCategory = "server"           // 5xx (재시도)
+	OCR_ERROR_CLIENT           OCRErrorCategory = "client"           // 4xx (즉시 실패)
+	OCR_ERROR_INVALID_RESPONSE OCRErrorCategory = "invalid_response" // 응답 파싱 실패 (즉시 실패)
+	OCR_ERROR_INFER_FAILED     OCRErrorCategory = "infer_failed"     // InferResult != SUCCESS (즉시 실패)
+	OCR_ERROR_CANCELLED        OCRErrorCategory = "cancelled"        // 사용자 취소
+	OCR_ERROR_INTERNAL         OCRErrorCategory = "internal"         // 분류되지 않은 내부 오류 (즉시 실패)
+	OCR_ERROR_CONFIG           OCRErrorCategory = "config"           // 서버 설정 문제 (녹화본 없음 등, 즉시 실패)
+)
+
+// 재시도 가능한 분류인지 확인
+func (c OCRErrorCategory) IsRetryable() bool {
+	switch c {
+	case OCR_ERROR_NETWORK, OCR_ERROR_RATE_LIMITED, OCR_ERROR_SERVER:
+		return true
+	default:
+		return false
+	}
+}
+
+// 분류별 사용자 안내 문구
+func (c OCRErrorCategory) SuggestedAction() string {
+	switch c {
+	case OCR_ERROR_NETWORK:
+		return "OCR 서버에 연결하지 못했습니다. 잠시 후 다시 시도해주세요"
+	case OCR_ERROR_RATE_LIMITED:
+		return "요청이 많아 처리가 지연되었습니다. 잠시 후 다시 시도해주세요"
+	case OCR_ERROR_SERVER:
+		return "OCR 서버 오류입니다. 잠시 후 다시 시도해주세요"
+	case OCR_ERROR_CLIENT:
+		return "요청이 거부되었습니다. 파일 형식과 크기를 확인하고, 계속되면 관리자에게 문의해주세요"
+	case OCR_ERROR_INVALID_RESPONSE:
+		return "OCR 응답을 해석할 수 없습니다. 관리자에게 문의해주세요"
+	case OCR_ERROR_INFER_FAILED:
+		return "영수증을 인식하지 못했습니다. 영수증 전체가 선명하게 나온 사진으로 다시 업로드해주세요"
+	case OCR_ERROR_CANCELLED:
+		return "처리가 취소되었습니다. 필요하면 다시 업로드해주세요"
+	case OCR_ERROR_INTERNAL:
+		return "처리 중 내부 오류가 발생했습니다. 계속되면 관리자에게 문의해주세요"
+	case OCR_ERROR_CONFIG:
+		return "OCR 서버 설정 문제로 처리하지 못했습니다. 관리자에게 문의해주세요"
+	default:
+		return "다시 시도해주세요"
+	}
+}
+
+// OCRError 분류 정보를 포함한 OCR 오류
+type OCRError struct {
+	Category     OCRErrorCategory
+	StatusCode   int           // HTTP 상태 코드 (없으면 0)
+	InferResult  string        // OCR 처리 결과 (infer_failed인 경우)
+	InferMessage string        // OCR 처리 메시지 (infer_failed인 경우)
+	RetryAfter   time.Duration // 서버가 지정한 재시도 대기 시간
+	Err          error
+}
+
+func (e *OCRError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *OCRError) Unwrap() error {
+	return e.Err
+}
+
+// 분류된 OCR 오류 생성
+func newOCRError(category OCRErrorCategory, format string, args ...interface{}) *OCRError {
+	return &OCRError{
+		Category: category,
+		Err:      fmt.Errorf(format, args...),
+	}
+}
+
+// HTTP 응답 상태 코드로 OCR 오류 생성
+func newOCRHTTPError(resp *http.Response, format string, args ...interface{}) *OCRError {
+	ocrErr := newOCRError(classifyHTTPStatus(resp.StatusCode), format, args...)
+	ocrErr.StatusCode = resp.StatusCode
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		ocrErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return ocrErr
+}
+
+// HTTP 상태 코드 분류
+func classifyHTTPStatus(statusCode int) OCRErrorCategory {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return OCR_ERROR_RATE_LIMITED
+	case statusCode >= 500:
+		return OCR_ERROR_SERVER
+	default:
+		return OCR_ERROR_CLIENT
+	}
+}
+
+// 임의의 오류에서 분류 추출 (실제 네트워크 오류만 재시도 대상, 나머지는 내부 오류)
+func getOCRErrorCategory(err error) OCRErrorCategory {
+	var ocrErr *OCRError
+	if errors.As(err, &ocrErr) {
+		return ocrErr.Category
+	}
+	return classifyRequestError(err)
+}
+
+// HTTP 요청/응답 읽기 오류 분류 (연결 실패, 타임아웃, 연결 끊김만 네트워크 오류)
+func classifyRequestError(err error) OCRErrorCategory {
+	// *url.Error도 net.Error를 구현하므로 실제 소켓/DNS 오류와 타임아웃만 확인
+	var netErr net.Error
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return OCR_ERROR_CANCELLED
+	case errors.As(err, &opErr), errors.As(err, &dnsErr),
+		errors.As(err, &netErr) && netErr.Timeout(),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, os.ErrDeadlineExceeded),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.EPIPE),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return OCR_ERROR_NETWORK
+	default:
+		return OCR_ERROR_INTERNAL
+	}
+}
+
+// 실패한 이미지 결과를 사용자 응답용 실패 정보로 변환
+func newOCRFailure(result *SingleImageOCRResult) OCRFailure {
+	failure := OCRFailure{
+		FileName:        result.ImageName,
+		ErrorClass:      result.ErrorCategory,
+		Retryable:       result.ErrorCategory.IsRetryable(),
+		SuggestedAction: result.ErrorCategory.SuggestedAction(),
+	}
+	if result.Error != nil {
+		failure.Error = result.Error.Error()
+	}
+
+	var ocrErr *OCRError
+	if errors.As(result.Error, &ocrErr) {
+		failure.InferResult = ocrErr.InferResult
+		failure.InferMessage = ocrErr.InferMessage
+	}
+	return failure
+}
+
+// 재시도 대기 시간 계산 (지수 백오프 + full jitter)
+func calculateRetryDelay(attempt int, err error) time.Duration {
+	baseDelay := time.Duration(getOCRRetryBaseDelayMs()) * time.Millisecond
+	maxDelay := time.Duration(getOCRRetryMaxDelayMs()) * time.Millisecond
+
+	// 서버가 Retry-After를 지정한 경우 우선 사용
+	var ocrErr *OCRError
+	if errors.As(err, &ocrErr) && ocrErr.RetryAfter > 0 {
+		if ocrErr.RetryAfter > maxDelay {
+			return maxDelay
+		}
+		return ocrErr.RetryAfter
+	}
+
+	backoff := baseDelay << attempt
+	if backoff <= 0 || backoff > maxDelay {
+		backoff = maxDelay
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// 재시도 가능한 오류에 대해 백오프 후 재호출
+func retryOCRCall(ctx context.Context, index int, call func() (*OCRProviderResponse, error)) (*OCRProviderResponse, error) {
+	maxRetries := getOCRMaxRetries()
+
+	for attempt := 0; ; attempt++ {
+		if ctx.Err() != nil {
+			return nil, newOCRError(OCR_ERROR_CANCELLED, "OCR 처리가 취소되었습니다")
+		}
+
+		response, err := call()
+		if err == nil {
+			return response, nil
+		}
+
+		category := getOCRErrorCategory(err)
+		if !category.IsRetryable() || attempt >= maxRetries {
+			if attempt > 0 {
+				log.Printf("❌ 이미지 %d OCR 재시도 중단 (시도: %d회, 분류: %s): %v", index+1, attempt+1, category, err)
+			}
+			return nil, err
+		}
+
+		delay := calculateRetryDelay(attempt, err)
+		log.Printf("🔁 이미지 %d OCR 재시도 %d/%d (분류: %s, 대기: %v): %v",
+			index+1, attempt+1, maxRetries, category, delay, err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, newOCRError(OCR_ERROR_CANCELLED, "OCR 처리가 취소되었습니다")
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OCR 작업 상태
+const (
+	JOB_STATUS_QUEUED    = "queued"
+	JOB_STATUS_RUNNING   = "running"
+	JOB_STATUS_COMPLETED = "completed"
+	JOB_STATUS_FAILED    = "failed"
+	JOB_STATUS_CANCELLED = "cancelled"
+)
+
+// 작업 내 이미지별 상태
+const (
+	JOB_IMAGE_QUEUED     = "queued"
+	JOB_IMAGE_PROCESSING = "processing"
+	JOB_IMAGE_COMPLETED  = "completed"
+	JOB_IMAGE_FAILED     = "failed"
+)
+
+// OCRJob 비동기 OCR 배치 작업
+type OCRJob struct {
+	mu         sync.Mutex
+	id         string
+	status     string
+	message    string
+	createdAt  time.Time
+	updatedAt  time.Time
+	finishedAt time.Time
+	expiresAt  time.Time // 종료 시점에 설정 (진행 중에는 zero)
+	images     []OCRJobImage
+	results    []OCRResult
+	failures   []OCRFailure
+	cancel     context.CancelFunc
+	upload     *ocrUpload // 진행 중에만 보관 (종료 시 nil)
+
+	subscribers map[chan OCRJobEvent]struct{} // SSE 구독자
+}
+
+// OCRJobEvent 작업 진행 이벤트 (SSE로 전달)
+type OCRJobEvent struct {
+	Type             string             `json:"type"`
+	JobID            string             `json:"jobId"`
+	Index            int                `json:"index"`
+	FileName         string             `json:"fileName"`
+	OriginalCategory string             `json:"originalCategory,omitempty"`
+	Category         string             `json:"category,omitempty"`
+	CategoryRule     *CategoryRuleMatch `json:"categoryRule,omitempty"`
+	ErrorCategory    OCRErrorCategory   `json:"errorCategory,omitempty"`
+	Error            string             `json:"error,omitempty"`
+	Results          []OCRResult        `json:"results,omitempty"` // completed 이벤트의 조기 결과
+	Failure          *OCRFailure        `json:"failure,omitempty"` // failed 이벤트의 실패 정보
+	Processed        int                `json:"processed"`
+	Total            int                `json:"total"`
+}
+
+// OCRJobImage 작업 내 이미지별 진행 상황
+type OCRJobImage struct {
+	Index         int              `json:"index"`
+	FileName      string           `json:"fileName"`
+	Status        string           `json:"status"`
+	ErrorCategory OCRErrorCategory `json:"errorCategory,omitempty"`
+	Error         string           `json:"error,omitempty"`
+}
+
+// OCRJobSnapshot 작업 상태 조회 응답
+type OCRJobSnapshot struct {
+	ID         string        `json:"id"`
+	Status     string        `json:"status"`
+	Message    string        `json:"message"`
+	Total      int           `json:"total"`
+	Processed  int           `json:"processed"`
+	Succeeded  int           `json:"succeeded"`
+	CacheHits  int           `json:"cacheHits"`
+	Failed     int           `json:"failed"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+	ExpiresAt  *time.Time    `json:"expiresAt,omitempty"`
+	Images     []OCRJobImage `json:"images"`
+	Data       []OCRResult   `json:"data"`
+	Failures   []OCRFailure  `json:"failures"`
+}
+
+// OCRJobStore 프로세스 내 작업 저장소 (TTL 경과 시 자동 삭제)
+type OCRJobStore struct {
+	mu   sync.RWMutex
+	jobs map[string]*OCRJob
+	ttl  time.Duration
+}
+
+var (
+	ocrJobStore     *OCRJobStore
+	ocrJobStoreOnce sync.Once
+)
+
+// 전역 작업 저장소 반환 (최초 호출 시 생성)
+func getOCRJobStore() *OCRJobStore {
+	ocrJobStoreOnce.Do(func() {
+		ocrJobStore = NewOCRJobStore(time.Duration(getOCRJobTTLMinutes()) * time.Minute)
+	})
+	return ocrJobStore
+}
+
+// 작업 저장소 생성자
+func NewOCRJobStore(ttl time.Duration) *OCRJobStore {
+	store := &OCRJobStore{
+		jobs: make(map[string]*OCRJob),
+		ttl:  ttl,
+	}
+	go store.cleanupLoop()
+	return store
+}
+
+// 새 작업 등록 후 백그라운드에서 실행
+func (st *OCRJobStore) Start(ocrService *OCRService, upload *ocrUpload) *OCRJob {
+	now := time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	job := &OCRJob{
+		id:        uuid.New().String(),
+		status:    JOB_STATUS_QUEUED,
+		message:   "작업이 대기 중입니다",
+		createdAt: now,
+		updatedAt: now,
+		cancel:    cancel,
+		upload:    upload,
+
+		subscribers: make(map[chan OCRJobEvent]struct{}),
+	}
+	for i, imageFile := range upload.ImageFiles {
+		job.images = append(job.images, OCRJobImage{
+			Index:    i,
+			FileName: imageFile.Filename,
+			Status:   JOB_IMAGE_QUEUED,
+		})
+	}
+
+	st.mu.Lock()
+	st.jobs[job.id] = job
+	st.mu.Unlock()
+
+	log.Printf("📦 OCR 작업 등록: %s (이미지 %d개)", job.id, len(job.images))
+	go job.run(ctx, ocrService, st.ttl)
+	return job
+}
+
+// 작업 조회
+func (st *OCRJobStore) Get(id string) (*OCRJob, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	job, exists := st.jobs[id]
+	return job, exists
+}
+
+// 만료된 작업 주기적 삭제 (대기/실행 중인 작업은 삭제하지 않음)
+func (st *OCRJobStore) cleanupLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		st.mu.Lock()
+		for id, job := range st.jobs {
+			if job.isExpired(now) {
+				job.cancel()
+				delete(st.jobs, id)
+				log.Printf("🗑️ 만료된 OCR 작업 삭제: %s", id)
+			}
+		}
+		st.mu.Unlock()
+	}
+}
+
+// 작업 실행 (진행 콜백으로 이미지별 상태와 부분 결과 갱신)
+func (job *OCRJob) run(ctx context.Context, ocrService *OCRService, ttl time.Duration) {
+	job.update(func() {
+		job.status = JOB_STATUS_RUNNING
+		job.message = "OCR 처리 중입니다"
+	})
+
+	ocrService.ProcessImagesWithProgress(ctx, job.upload.ImageFiles, job.upload.DateContext, job.handleProgress)
+
+	job.update(func() {
+		succeeded, failed := job.countLocked()
+		switch {
+		case ctx.Err() != nil:
+			job.status = JOB_STATUS_CANCELLED
+			job.message = fmt.Sprintf("작업이 취소되었습니다 (완료 %d개)", succeeded)
+		case succeeded == 0:
+			job.status = JOB_STATUS_FAILED
+			job.message = "모든 이미지의 OCR 처리에 실패했습니다"
+		default:
+			job.status = JOB_STATUS_COMPLETED
+			job.message = fmt.Sprintf("%d개 파일의 OCR 처리가 완료되었습니다 (실패 %d개)", succeeded, failed)
+		}
+		sortResultsByIssueDate(job.results)
+		job.finishedAt = time.Now()
+		job.expiresAt = job.finishedAt.Add(ttl)
+
+		// 종료 후에는 결과만 제공하므로 업로드 이미지 데이터 해제
+		job.upload = nil
+
+		// 구독 채널 종료 (구독자는 종료 후 최종 스냅샷을 조회)
+		for subscriber := range job.subscribers {
+			close(subscriber)
+		}
+		job.subscribers = nil
+	})
+
+	job.cancel()
+	log.Printf("📦 OCR 작업 종료: %s (%s)", job.id, job.Snapshot().Status)
+}
+
+// 이미지별 진행 이벤트 반영
+func (job *OCRJob) handleProgress(event OCRProgressEvent) {
+	// 결과 변환은 잠금 밖에서 수행
+	var converted []OCRResult
+	if event.Type == OCR_EVENT_COMPLETED {
+		converted = convertOCRResults([]*SingleImageOCRResultWithCategory{event.Result}, job.upload)
+	}
+
+	job.update(func() {
+		jobEvent := OCRJobEvent{
+			Type:     event.Type,
+			JobID:    job.id,
+			Index:    event.Index,
+			FileName: event.FileName,
+		}
+
+		image := &job.images[event.Index]
+		switch event.Type {
+		case OCR_EVENT_STARTED:
+			image.Status = JOB_IMAGE_PROCESSING
+		case OCR_EVENT_COMPLETED:
+			image.Status = JOB_IMAGE_COMPLETED
+			for i := range converted {
+				converted[i].Duplicate = detectDuplicateReceipt(converted[i], job.results)
+				job.results = append(job.results, converted[i])
+			}
+			jobEvent.Results = converted
+		case OCR_EVENT_FAILED:
+			failure := newOCRFailure(&event.Result.SingleImageOCRResult)
+			image.Status = JOB_IMAGE_FAILED
+			image.ErrorCategory = event.Result.ErrorCategory
+			image.Error = failure.Error
+			job.failures = append(job.failures, failure)
+			jobEvent.ErrorCategory = image.ErrorCategory
+			jobEvent.Error = image.Error
+			jobEvent.Failure = &failure
+		case OCR_EVENT_CATEGORY_ADJUSTED:
+			jobEvent.OriginalCategory = event.OriginalCategory
+			jobEvent.Category = event.Category
+			jobEvent.CategoryRule = event.CategoryRule
+		}
+
+		succeeded, failed := job.countLocked()
+		jobEvent.Processed = succeeded + failed
+		jobEvent.Total = len(job.images)
+		job.publishLocked(jobEvent)
+	})
+}
+
+// 작업 이벤트 구독 (작업이 이미 종료되었으면 닫힌 채널 반환)
+func (job *OCRJob) Subscribe() (<-chan OCRJobEvent, func()) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+
+	subscriber := make(chan OCRJobEvent, 64)
+	if job.subscribers == nil {
+		close(subscriber)
+		return subscriber, func() {}
+	}
+
+	job.subscribers[subscriber] = struct{}{}
+	unsubscribe := func() {
+		job.mu.Lock()
+		defer job.mu.Unlock()
+		if _, exists := job.subscribers[subscriber]; exists {
+			delete(job.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+	return subscriber, unsubscribe
+}
+
+// 구독자에게 이벤트 전달 (mu 보유 상태에서 호출, 느린 구독자는 이벤트 누락)
+func (job *OCRJob) publishLocked(event OCRJobEvent) {
+	for subscriber := range job.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			log.Printf("⚠️ OCR 작업 %s 이벤트 누락 (구독자 버퍼 가득 참): %s", job.id, event.Type)
+		}
+	}
+}
+
+// 작업 취소 (이미 종료된 작업이면 false)
+func (job *OCRJob) Cancel() bool {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+
+	if job.status != JOB_STATUS_QUEUED && job.status != JOB_STATUS_RUNNING {
+		return false
+	}
+	job.cancel()
+	job.message = "작업 취소 요청됨"
+	job.updatedAt = time.Now()
+	log.Printf("⛔ OCR 작업 취소 요청: %s", job.id)
+	return true
+}
+
+// 작업 상태 스냅샷
+func (job *OCRJob) Snapshot() OCRJobSnapshot {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+
+	succeeded, failed := job.countLocked()
+	snapshot := OCRJobSnapshot{
+		ID:        job.id,
+		Status:    job.status,
+		Message:   job.message,
+		Total:     len(job.images),
+		Processed: succeeded + failed,
+		Succeeded: succeeded,
+		CacheHits: countOCRCacheHits(job.results),
+		Failed:    failed,
+		CreatedAt: job.createdAt,
+		UpdatedAt: job.updatedAt,
+		Images:    append([]OCRJobImage(nil), job.images...),
+		Data:      append([]OCRResult{}, job.results...),
+		Failures:  append([]OCRFailure{}, job.failures...),
+	}
+	if !job.finishedAt.IsZero() {
+		finishedAt := job.finishedAt
+		snapshot.FinishedAt = &finishedAt
+	}
+	if !job.expiresAt.IsZero() {
+		expiresAt := job.expiresAt
+		snapshot.ExpiresAt = &expiresAt
+	}
+	return snapshot
+}
+
+func (job *OCRJob) update(apply func()) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	apply()
+	job.updatedAt = time.Now()
+}
+
+// 성공/실패 이미지 수 (mu 보유 상태에서 호출)
+func (job *OCRJob) countLocked() (succeeded, failed int) {
+	for _, image := range job.images {
+		switch image.Status {
+		case JOB_IMAGE_COMPLETED:
+			succeeded++
+		case JOB_IMAGE_FAILED:
+			failed++
+		}
+	}
+	return succeeded, failed
+}
+
+// 종료 후 보관 기간이 지났는지 확인 (종료되지 않은 작업은 만료되지 않음)
+func (job *OCRJob) isExpired(now time.Time) bool {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if job.status == JOB_STATUS_QUEUED || job.status == JOB_STATUS_RUNNING || job.expiresAt.IsZero() {
+		return false
+	}
+	return now.After(job.expiresAt)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OCRWorkerPool 프로세스 전역 OCR 작업 풀 (동시 실행 수 + 초당 호출 수 제한)
+type OCRWorkerPool struct {
+	tasks   chan ocrTask
+	limiter *TokenBucket
+	workers int
+
+	queued        int64 // 대기 중인 작업 수
+	active        int64 // 실행 중인 작업 수
+	submitted     int64
+	completed     int64
+	totalWaitNs   int64 // 큐 대기 시간 합계
+	maxWaitNs     int64
+	rateLimitWait int64 // 토큰 대기 시간 합계
+}
+
+type ocrTask struct {
+	run        func()
+	enqueuedAt time.Time
+}
+
+// OCRPoolStats 작업 풀 통계
+type OCRPoolStats struct {
+	Workers            int     `json:"workers"`
+	QueueDepth         int64   `json:"queueDepth"`
+	ActiveTasks        int64   `json:"activeTasks"`
+	SubmittedTasks     int64   `json:"submittedTasks"`
+	CompletedTasks     int64   `json:"completedTasks"`
+	AverageWaitMs      float64 `json:"averageWaitMs"`
+	MaxWaitMs          float64 `json:"maxWaitMs"`
+	RateLimitQPS       float64 `json:"rateLimitQps"`
+	RateLimitBurst     int     `json:"rateLimitBurst"`
+	RateLimitWaitMsSum float64 `json:"rateLimitWaitMsSum"`
+}
+
+var (
+	ocrWorkerPool     *OCRWorkerPool
+	ocrWorkerPoolOnce sync.Once
+)
+
+// 전역 OCR 작업 풀 반환 (최초 호출 시 생성)
+func getOCRWorkerPool() *OCRWorkerPool {
+	ocrWorkerPoolOnce.Do(func() {
+		ocrWorkerPool = NewOCRWorkerPool(getOCRMaxConcurrency(), getOCRQueueSize(),
+			getOCRRateLimitQPS(), getOCRRateLimitBurst())
+	})
+	return ocrWorkerPool
+}
+
+// OCR 작업 풀 생성자
+func NewOCRWorkerPool(workers, queueSize int, qps float64, burst int) *OCRWorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < workers {
+		queueSize = workers
+	}
+
+	pool := &OCRWorkerPool{
+		tasks:   make(chan ocrTask, queueSize),
+		limiter: NewTokenBucket(qps, burst),
+		workers: workers,
+	}
+
+	for i := 0; i < workers; i++ {
+		go pool.worker()
+	}
+
+	log.Printf("OCR 작업 풀 시작: 동시 실행 %d개, 큐 크기 %d, 속도 제한 %.2f QPS (burst %d)",
+		workers, queueSize, qps, burst)
+	return pool
+}
+
+// 작업 제출 (큐가 가득 차면 빈 자리가 생길 때까지 대기)
+func (p *OCRWorkerPool) Submit(run func()) {
+	atomic.AddInt64(&p.queued, 1)
+	atomic.AddInt64(&p.submitted, 1)
+	p.tasks <- ocrTask{run: run, enqueuedAt: time.Now()}
+}
+
+func (p *OCRWorkerPool) worker() {
+	for task := range p.tasks {
+		waitNs := int64(time.Since(task.enqueuedAt))
+		atomic.AddInt64(&p.queued, -1)
+		atomic.AddInt64(&p.totalWaitNs, waitNs)
+		for {
+			maxWait := atomic.LoadInt64(&p.maxWaitNs)
+			if waitNs <= maxWait || atomic.CompareAndSwapInt64(&p.maxWaitNs, maxWait, waitNs) {
+				break
+			}
+		}
+
+		atomic.AddInt64(&p.active, 1)
+		task.run()
+		atomic.AddInt64(&p.active, -1)
+		atomic.AddInt64(&p.completed, 1)
+	}
+}
+
+// OCR API 호출 전 속도 제한 토큰 획득 (대기 중 취소되면 오류 반환)
+func (p *OCRWorkerPool) WaitForRateLimit(ctx context.Context) error {
+	waited, err := p.limiter.Wait(ctx)
+	if waited > 0 {
+		atomic.AddInt64(&p.rateLimitWait, int64(waited))
+	}
+	return err
+}
+
+// 작업 풀 통계 조회
+func (p *OCRWorkerPool) Stats() OCRPoolStats {
+	completed := atomic.LoadInt64(&p.completed)
+	submitted := atomic.LoadInt64(&p.submitted)
+	queued := atomic.LoadInt64(&p.queued)
+
+	averageWaitMs := 0.0
+	if started := submitted - queued; started > 0 {
+		averageWaitMs = float64(atomic.LoadInt64(&p.totalWaitNs)) / float64(started) / float64(time.Millisecond)
+	}
+
+	return OCRPoolStats{
+		Workers:            p.workers,
+		QueueDepth:         queued,
+		ActiveTasks:        atomic.LoadInt64(&p.active),
+		SubmittedTasks:     submitted,
+		CompletedTasks:     completed,
+		AverageWaitMs:      averageWaitMs,
+		MaxWaitMs:          float64(atomic.LoadInt64(&p.maxWaitNs)) / float64(time.Millisecond),
+		RateLimitQPS:       p.limiter.rate,
+		RateLimitBurst:     p.limiter.burst,
+		RateLimitWaitMsSum: float64(atomic.LoadInt64(&p.rateLimitWait)) / float64(time.Millisecond),
+	}
+}
+
+// TokenBucket 토큰 버킷 속도 제한기 (rate <= 0이면 제한 없음)
+type TokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // 초당 충전 토큰 수
+	burst    int
+	tokens   float64
+	lastFill time.Time
+}
+
+// 토큰 버킷 생성자
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:     rate,
+		burst:    burst,
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// 토큰 1개를 획득할 때까지 대기하고 대기 시간을 반환
+func (b *TokenBucket) Wait(ctx context.Context) (time.Duration, error) {
+	if b.rate <= 0 {
+		return 0, nil
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.lastFill).Seconds() * b.rate
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	b.lastFill = now
+
+	// 토큰을 미리 차감하고 부족분만큼 대기 (대기 순서 보장)
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if wait <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	startTime := time.Now()
+	select {
+	case <-timer.C:
+		return wait, nil
+	case <-ctx.Done():
+		// 사용하지 않은 토큰 반환
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return time.Since(startTime), ctx.Err()
+	}
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OCR 제공자 종류
+const (
+	OCR_PROVIDER_CLOVA = "clova"
+	OCR_PROVIDER_LOCAL = "local"
+)
+
+// OCRProvider OCR 엔진 추상화 (벤더 교체 시 이 인터페이스만 구현)
+type OCRProvider interface {
+	Name() string
+	Recognize(ctx context.Context, imageFile ImageFile, index int) (*OCRProviderResponse, error)
+}
+
+// OCRProviderResponse 제공자 응답 (파싱된 결과 + 원본 응답 본문 + 전송한 요청)
+type OCRProviderResponse struct {
+	Image         *OCRImageResult
+	RawBody       []byte
+	RequestBody   []byte          // 제공자에 전송한 요청 본문 (녹화 시 해시로 저장)
+	RequestFields json.RawMessage // 이미지 데이터를 뺀 요청 필드 (녹화용)
+}
+
+// 설정에 따라 OCR 제공자 생성 (녹화/재생 모드면 래핑)
+func NewOCRProvider(timeout time.Duration) (OCRProvider, error) {
+	recordMode := getOCRRecordMode()
+
+	var provider OCRProvider
+	switch providerName := getOCRProviderName(); {
+	case recordMode == OCR_RECORD_MODE_REPLAY:
+		// 재생 모드에서는 실제 제공자를 호출하지 않으므로 시크릿 없이 동작
+		provider = nil
+	case providerName == OCR_PROVIDER_CLOVA:
+		secret, err := getOCRSecret()
+		if err != nil {
+			return nil, err
+		}
+		clova := NewClovaOCRProvider(getOCRAPIURL(), secret, timeout)
+		clova.sendImageHash = isMockOCREnabled()
+		provider = clova
+	case providerName == OCR_PROVIDER_LOCAL:
+		provider = NewLocalOCRProvider(getLocalOCRURL(), timeout)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 OCR 제공자입니다: %s", providerName)
+	}
+
+	switch recordMode {
+	case OCR_RECORD_MODE_OFF:
+		return provider, nil
+	case OCR_RECORD_MODE_RECORD, OCR_RECORD_MODE_REPLAY:
+		return NewRecordingOCRProvider(provider, recordMode, getOCRRecordDir()), nil
+	default:
+		return nil, fmt.Errorf("지원하지 않는 OCR 녹화 모드입니다: %s", recordMode)
+	}
+}
+
+// ClovaOCRProvider 네이버 CLOVA 템플릿 OCR 클라이언트
+type ClovaOCRProvider struct {
+	apiURL        string
+	secretKey     string
+	client        *http.Client
+	sendImageHash bool // 내장 Mock OCR 사용 시 원본 해시 헤더 전송 (fixture 매칭용)
+}
+
+// CLOVA OCR 제공자 생성자
+func NewClovaOCRProvider(apiURL, secretKey string, timeout time.Duration) *ClovaOCRProvider {
+	return &ClovaOCRProvider{
+		apiURL:    apiURL,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *ClovaOCRProvider) Name() string {
+	return OCR_PROVIDER_CLOVA
+}
+
+// CLOVA OCR API 호출 (단일 이미지)
+func (p *ClovaOCRProvider) Recognize(ctx context.Context, imageFile ImageFile, index int) (*OCRProviderResponse, error) {
+	// Base64 인코딩
+	encodedImage := base64.StdEncoding.EncodeToString(imageFile.Data)
+	log.Printf("Base64 인코딩 완료: %d characters", len(encodedImage))
+
+	// OCR 요청 데이터 생성 (단일 이미지)
+	requestId := uuid.New().String()
+	timestamp := time.Now().UnixMilli()
+
+	ocrRequest := OCRRequest{
+		Version:   "V2",
+		RequestID: requestId,
+		Timestamp: timestamp,
+		Lang:      "ko",
+		Images: []OCRImage{
+			{
+				Format: imageFile.Format,
+				Name:   imageFile.Filename,
+				Data:   encodedImage,
+			},
+		},
+	}
+
+	log.Printf("이미지 %d OCR 요청 - Request ID: %s", index+1, requestId)
+
+	// JSON 직렬화
+	requestBody, err := json.Marshal(ocrRequest)
+	if err != nil {
+		return nil, newOCRError(OCR_ERROR_CLIENT, "OCR 요청 JSON 생성 실패: %v", err)
+	}
+
+	log.Printf("이미지 %d 요청 본문 크기: %d bytes", index+1, len(requestBody))
+
+	// 녹화용 요청 필드 (이미지 데이터는 본문 해시로 대체)
+	recordedRequest := ocrRequest
+	recordedRequest.Images = []OCRImage{{Format: imageFile.Format, Name: imageFile.Filename}}
+	requestFields, _ := json.Marshal(recordedRequest)
+
+	// OCR_VERBOSE_LOG가 설정된 경우에만 전체 요청 JSON 출력
+	if isOCRVerboseLog() {
+		log.Printf("=== 이미지 %d 전체 OCR 요청 JSON ===", index+1)
+		requestJson, _ := json.MarshalIndent(ocrRequest, "", "  ")
+		log.Printf("%s", string(requestJson))
+	}
+
+	// HTTP 요청 생성
+	// 작업 취소 시 진행 중인 요청도 중단
+	req, err := http.NewRequestWithContext(ctx, "POST", p.apiURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, newOCRError(OCR_ERROR_CLIENT, "HTTP 요청 생성 실패: %v", err)
+	}
+
+	// 헤더 설정
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-OCR-SECRET", p.secretKey)
+	if p.sendImageHash {
+		req.Header.Set(MOCK_OCR_IMAGE_HASH_HEADER, imageFile.sourceSHA256())
+	}
+
+	log.Printf("이미지 %d OCR API 호출 시작", index+1)
+	requestStartTime := time.Now()
+
+	resp, err := p.client.Do(req)
+	requestDuration := time.Since(requestStartTime)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, newOCRError(OCR_ERROR_CANCELLED, "OCR 처리가 취소되었습니다")
+		}
+		log.Printf("❌ 이미지 %d OCR API 요청 실패 (소요시간: %v): %v", index+1, requestDuration, err)
+		return nil, newOCRError(classifyRequestError(err), "OCR API 요청 실패: %v", err)
+	}
+	defer resp.Body.Close()
+
+	log.Printf("이미지 %d OCR API 응답 수신 (소요시간: %v)", index+1, requestDuration)
+	log.Printf("이미지 %d HTTP 상태: %d %s", index+1, resp.StatusCode, resp.Status)
+
+	// 응답 상태 코드 확인
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("❌ 이미지 %d OCR API 응답 오류: %d", index+1, resp.StatusCode)
+
+		// 에러 응답 body도 로깅
+		errorBody := make([]byte, 1024)
+		n, _ := resp.Body.Read(errorBody)
+		if n > 0 {
+			log.Printf("이미지 %d 에러 응답 내용: %s", index+1, string(errorBody[:n]))
+		}
+
+		return nil, newOCRHTTPError(resp, "OCR API 응답 오류: %d", resp.StatusCode)
+	}
+
+	// 응답 body 전체를 먼저 읽어서 로깅
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Printf("❌ 이미지 %d 응답 body 읽기 실패: %v", index+1, err)
+		return nil, newOCRError(classifyRequestError(err), "응답 body 읽기 실패: %v", err)
+	}
+
+	log.Printf("이미지 %d 응답 body 크기: %d bytes", index+1, len(responseBody))
+
+	// 응답 내용 로그 (OCR_VERBOSE_LOG 설정에 따라)
+	if isOCRVerboseLog() {
+		log.Printf("=== 이미지 %d 전체 OCR 응답 JSON ===", index+1)
+		log.Printf("%s", string(responseBody))
+	} else {
+		// 포매팅 없이 원본 그대로 출력
+		log.Printf("=== 이미지 %d OCR 응답 JSON (원본) ===\n%s", index+1, string(responseBody))
+	}
+
+	// 응답 JSON 파싱
+	var ocrResponse OCRResponse
+	if err := json.Unmarshal(responseBody, &ocrResponse); err != nil {
+		log.Printf("❌ 이미지 %d OCR 응답 파싱 실패: %v", index+1, err)
+		return nil, newOCRError(OCR_ERROR_INVALID_RESPONSE, "OCR 응답 파싱 실패: %v", err)
+	}
+
+	// 첫 번째 이미지 결과 반환
+	if len(ocrResponse.Images) == 0 {
+		return nil, newOCRError(OCR_ERROR_INVALID_RESPONSE, "OCR 응답에 이미지 결과가 없습니다")
+	}
+
+	return &OCRProviderResponse{
+		Image:         &ocrResponse.Images[0],
+		RawBody:       responseBody,
+		RequestBody:   requestBody,
+		RequestFields: requestFields,
+	}, nil
+}
+
+// LocalOCRProvider 로컬 OCR 엔진 클라이언트
+// 이미지를 multipart(image)로 전송하고 필드 목록 JSON을 응답으로 받는다.
+type LocalOCRProvider struct {
+	engineURL string
+	client    *http.Client
+}
+
+// 로컬 OCR 엔진 응답 구조체
+type LocalOCRResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Fields  []LocalOCRField `json:"fields"`
+}
+
+type LocalOCRField struct {
+	Name       string  `json:"name"`
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidence"`
+}
+
+// 로컬 OCR 제공자 생성자
+func NewLocalOCRProvider(engineURL string, timeout time.Duration) *LocalOCRProvider {
+	return &LocalOCRProvider{
+		engineURL: engineURL,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *LocalOCRProvider) Name() string {
+	return OCR_PROVIDER_LOCAL
+}
+
+// 로컬 OCR 엔진 호출 (단일 이미지)
+func (p *LocalOCRProvider) Recognize(ctx context.Context, imageFile ImageFile, index int) (*OCRProviderResponse, error) {
+	// multipart 본문 생성
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("image", imageFile.Filename)
+	if err != nil {
+		return nil, newOCRError(OCR_ERROR_CLIENT, "로컬 OCR 요청 생성 실패: %v", err)
+	}
+	if _, err := part.Write(imageFile.Data); err != nil {
+		return nil, newOCRError(OCR_ERROR_CLIENT, "로컬 OCR 요청 생성 실패: %v", err)
+	}
+	writer.WriteField("format", imageFile.Format)
+	writer.WriteField("lang", "ko")
+	if err := writer.Close(); err != nil {
+		return nil, newOCRError(OCR_ERROR_CLIENT, "로컬 OCR 요청 생성 실패: %v", err)
+	}
+
+	requestBody := body.Bytes()
+	requestFields, _ := json.Marshal(map[string]string{
+		"filename": imageFile.Filename,
+		"format":   imageFile.Format,
+		"lang":     "ko",
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.engineURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, newOCRError(OCR_ERROR_CLIENT, "HTTP 요청 생성 실패: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	log.Printf("이미지 %d 로컬 OCR 엔진 호출 시작: %s", index+1, p.engineURL)
+	requestStartTime := time.Now()
+
+	resp, err := p.client.Do(req)
+	requestDuration := time.Since(requestStartTime)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, newOCRError(OCR_ERROR_CANCELLED, "OCR 처리가 취소되었습니다")
+		}
+		log.Printf("❌ 이미지 %d 로컬 OCR 요청 실패 (소요시간: %v): %v", index+1, requestDuration, err)
+		return nil, newOCRError(classifyRequestError(err), "로컬 OCR 요청 실패: %v", err)
+	}
+	defer resp.Body.Close()
+
+	log.Printf("이미지 %d 로컬 OCR 응답 수신 (소요시간: %v, HTTP %d)", index+1, requestDuration, resp.StatusCode)
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newOCRError(classifyRequestError(err), "응답 body 읽기 실패: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("이미지 %d 에러 응답 내용: %s", index+1, truncateText(string(responseBody), 1024))
+		return nil, newOCRHTTPError(resp, "로컬 OCR 응답 오류: %d", resp.StatusCode)
+	}
+
+	if isOCRVerboseLog() {
+		log.Printf("=== 이미지 %d 로컬 OCR 응답 JSON ===\n%s", index+1, string(responseBody))
+	}
+
+	var localResponse LocalOCRResponse
+	if err := json.Unmarshal(responseBody, &localResponse); err != nil {
+		return nil, newOCRError(OCR_ERROR_INVALID_RESPONSE, "로컬 OCR 응답 파싱 실패: %v", err)
+	}
+
+	return &OCRProviderResponse{
+		Image:         localResponse.toImageResult(imageFile.Filename),
+		RawBody:       responseBody,
+		RequestBody:   requestBody,
+		RequestFields: requestFields,
+	}, nil
+}
+
+// 로컬 엔진 응답을 CLOVA 결과 형식으로 변환 (후속 처리 공통화)
+func (r *LocalOCRResponse) toImageResult(filename string) *OCRImageResult {
+	result := &OCRImageResult{
+		UID:     uuid.New().String(),
+		Name:    filename,
+		Message: r.Message,
+	}
+
+	if r.Success {
+		result.InferResult = "SUCCESS"
+	} else {
+		result.InferResult = "FAILURE"
+	}
+
+	for _, field := range r.Fields {
+		result.Fields = append(result.Fields, Field{
+			Name:            field.Name,
+			ValueType:       "ALL",
+			InferText:       field.Text,
+			InferConfidence: field.Confidence,
+		})
+	}
+
+	return result
+}
+
+// 로그 출력용 문자열 자르기
+func truncateText(text string, maxLength int) string {
+	if len(text) <= maxLength {
+		return text
+	}
+	return strings.ToValidUTF8(text[:maxLength], "") + "..."
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// OCR 녹화/재생 모드
+const (
+	OCR_RECORD_MODE_OFF    = "off"
+	OCR_RECORD_MODE_RECORD = "record"
+	OCR_RECORD_MODE_REPLAY = "replay"
+)
+
+// OCRRecording 이미지 1건의 OCR 요청/응답 기록 (파일명: <sha256>.json)
+type OCRRecording struct {
+	SHA256     string          `json:"sha256"`
+	Provider   string          `json:"provider"`
+	RecordedAt time.Time       `json:"recordedAt"`
+	Request    OCRRecordedCall `json:"request"`
+	Response   json.RawMessage `json:"response,omitempty"` // 제공자 원본 응답 본문
+	Image      *OCRImageResult `json:"image"`              // 파싱된 결과 (재생 시 사용)
+}
+
+// OCRRecordedCall 녹화된 요청 정보 (이미지 본문은 해시로 대체)
+type OCRRecordedCall struct {
+	Filename   string          `json:"filename"`
+	Format     string          `json:"format"`
+	Size       int             `json:"size"`
+	BodySHA256 string          `json:"bodySha256,omitempty"` // 제공자에 전송한 요청 본문 해시
+	BodySize   int             `json:"bodySize,omitempty"`
+	Fields     json.RawMessage `json:"fields,omitempty"` // 이미지 데이터를 뺀 제공자 요청 필드
+}
+
+// RecordingOCRProvider 다른 제공자를 감싸 응답을 녹화하거나 녹화본을 재생
+type RecordingOCRProvider struct {
+	inner OCRProvider
+	mode  string
+	dir   string
+}
+
+// 녹화/재생 제공자 생성자 (재생 모드에서는 inner가 nil일 수 있음)
+func NewRecordingOCRProvider(inner OCRProvider, mode, dir string) *RecordingOCRProvider {
+	return &RecordingOCRProvider{
+		inner: inner,
+		mode:  mode,
+		dir:   dir,
+	}
+}
+
+func (p *RecordingOCRProvider) Name() string {
+	if p.inner == nil {
+		return p.mode
+	}
+	return p.inner.Name() + "+" + p.mode
+}
+
+func (p *RecordingOCRProvider) Recognize(ctx context.Context, imageFile ImageFile, index int) (*OCRProviderResponse, error) {
+	imageHash := imageFile.sourceSHA256()
+
+	if p.mode == OCR_RECORD_MODE_REPLAY {
+		return p.replay(imageHash, imageFile, index)
+	}
+
+	response, err := p.inner.Recognize(ctx, imageFile, index)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.record(imageHash, imageFile, response); err != nil {
+		// 녹화 실패는 OCR 결과에 영향을 주지 않음
+		log.Printf("⚠️ 이미지 %d OCR 응답 녹화 실패: %v", index+1, err)
+	}
+
+	return response, nil
+}
+
+// 녹화본에서 응답 재생
+func (p *RecordingOCRProvider) replay(imageHash string, imageFile ImageFile, index int) (*OCRProviderResponse, error) {
+	data, err := os.ReadFile(p.recordingPath(imageHash))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, newOCRError(OCR_ERROR_CONFIG, "녹화된 OCR 응답이 없습니다: %s (sha256: %s)", imageFile.Filename, imageHash)
+		}
+		return nil, newOCRError(OCR_ERROR_CONFIG, "OCR 녹화본 읽기 실패: %v", err)
+	}
+
+	var recording OCRRecording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, newOCRError(OCR_ERROR_INVALID_RESPONSE, "OCR 녹화본 파싱 실패: %v", err)
+	}
+	if recording.Image == nil {
+		return nil, newOCRError(OCR_ERROR_INVALID_RESPONSE, "OCR 녹화본에 결과가 없습니다: %s", imageHash)
+	}
+
+	log.Printf("▶️ 이미지 %d OCR 응답 재생: %s (녹화: %s, 제공자: %s)",
+		index+1, imageFile.Filename, recording.RecordedAt.Format(time.RFC3339), recording.Provider)
+
+	return &OCRProviderResponse{
+		Image:   recording.Image,
+		RawBody: recording.Response,
+	}, nil
+}
+
+// 요청/응답 쌍을 디렉터리에 저장
+func (p *RecordingOCRProvider) record(imageHash string, imageFile ImageFile, response *OCRProviderResponse) error {
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return err
+	}
+
+	recording := OCRRecording{
+		SHA256:     imageHash,
+		Provider:   p.inner.Name(),
+		RecordedAt: time.Now(),
+		Request: OCRRecordedCall{
+			Filename: imageFile.Filename,
+			Format:   imageFile.Format,
+			Size:     len(imageFile.Data),
+		},
+		Image: response.Image,
+	}
+	if len(response.RequestBody) > 0 {
+		recording.Request.BodySHA256 = imageSHA256(response.RequestBody)
+		recording.Request.BodySize = len(response.RequestBody)
+	}
+	if json.Valid(response.RequestFields) {
+		recording.Request.Fields = response.RequestFields
+	}
+	if json.Valid(response.RawBody) {
+		recording.Response = response.RawBody
+	}
+
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// 임시 파일에 쓴 뒤 이름 변경 (동시 요청 시 부분 기록 방지)
+	path := p.recordingPath(imageHash)
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return err
+	}
+
+	log.Printf("⏺️ OCR 응답 녹화: %s → %s", imageFile.Filename, path)
+	return nil
+}
+
+func (p *RecordingOCRProvider) recordingPath(imageHash string) string {
+	return filepath.Join(p.dir, imageHash+".json")
+}
+
+// 이미지 바이트의 SHA-256 해시 (hex)
+func imageSHA256(data []byte) string {
+	hashSum := sha256.Sum256(data)
+	return hex.EncodeToString(hashSum[:])
+}
+
+// 전처리 전 원본 기준 이미지 해시 (전처리로 바이트가 바뀌어도 녹화/fixture 키 유지)
+func (f ImageFile) sourceSHA256() string {
+	if f.SHA256 != "" {
+		return f.SHA256
+	}
+	return imageSHA256(f.Data)
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// 중복 유형
+const (
+	DUPLICATE_TYPE_IMAGE   = "exact_image"  // 동일한 이미지 파일
+	DUPLICATE_TYPE_RECEIPT = "same_receipt" // 사용처 + 금액 + 사용일시가 같은 영수증
+)
+
+// 중복 발견 범위
+const (
+	DUPLICATE_SCOPE_BATCH   = "batch"   // 같은 업로드 내
+	DUPLICATE_SCOPE_HISTORY = "history" // 이전에 Excel로 내보낸 내역
+)
+
+// Excel 내보내기 시 중복 처리 정책
+const (
+	DUPLICATE_POLICY_OFF   = "off"
+	DUPLICATE_POLICY_WARN  = "warn"
+	DUPLICATE_POLICY_BLOCK = "block"
+)
+
+// DuplicateInfo 중복 의심 정보 (먼저 등록된 항목 참조)
+type DuplicateInfo struct {
+	Type               string     `json:"type"`
+	Scope              string     `json:"scope"`
+	ReferenceFileName  string     `json:"referenceFileName"`
+	ReferenceUserName  string     `json:"referenceUserName,omitempty"`
+	ReferenceClaimedAt *time.Time `json:"referenceClaimedAt,omitempty"`
+}
+
+// ReceiptHistoryRecord 내보낸 영수증 기록
+type ReceiptHistoryRecord struct {
+	BatchID    string    `json:"batchId,omitempty"` // 내보낸 결과의 OCR 업로드 배치
+	ImageHash  string    `json:"imageHash,omitempty"`
+	ReceiptKey string    `json:"receiptKey,omitempty"`
+	FileName   string    `json:"fileName"`
+	UserName   string    `json:"userName"`
+	ClaimedAt  time.Time `json:"claimedAt"`
+}
+
+// ReceiptHistoryStore 파일 기반 영수증 내보내기 이력
+type ReceiptHistoryStore struct {
+	mu      sync.RWMutex
+	path    string
+	records []ReceiptHistoryRecord
+}
+
+var (
+	receiptHistoryStore     *ReceiptHistoryStore
+	receiptHistoryStoreOnce sync.Once
+)
+
+// 전역 영수증 이력 저장소 반환 (최초 호출 시 파일에서 로드)
+func getReceiptHistoryStore() *ReceiptHistoryStore {
+	receiptHistoryStoreOnce.Do(func() {
+		receiptHistoryStore = NewReceiptHistoryStore(getDuplicateHistoryFile())
+	})
+	return receiptHistoryStore
+}
+
+// 영수증 이력 저장소 생성자
+func NewReceiptHistoryStore(path string) *ReceiptHistoryStore {
+	store := &ReceiptHistoryStore{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("⚠️ 영수증 이력 파일 읽기 실패 (%s): %v", path, err)
+		}
+		return store
+	}
+	if err := json.Unmarshal(data, &store.records); err != nil {
+		log.Printf("⚠️ 영수증 이력 파일 파싱 실패 (%s): %v", path, err)
+		store.records = nil
+	}
+	store.pruneLocked()
+	log.Printf("영수증 이력 로드: %d건 (%s)", len(store.records), path)
+	return store
+}
+
+// 이미지 해시 또는 영수증 키가 일치하는 이력 조회 (같은 배치에서 내보낸 이력은 제외)
+func (s *ReceiptHistoryStore) Find(imageHash, receiptKey, batchID string) (*ReceiptHistoryRecord, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := range s.records {
+		if batchID != "" && s.records[i].BatchID == batchID {
+			continue
+		}
+		if imageHash != "" && s.records[i].ImageHash == imageHash {
+			record := s.records[i]
+			return &record, DUPLICATE_TYPE_IMAGE
+		}
+	}
+	for i := range s.records {
+		if batchID != "" && s.records[i].BatchID == batchID {
+			continue
+		}
+		if receiptKey != "" && s.records[i].ReceiptKey == receiptKey {
+			record := s.records[i]
+			return &record, DUPLICATE_TYPE_RECEIPT
+		}
+	}
+	return nil, ""
+}
+
+// 내보낸 영수증을 이력에 추가하고 파일에 저장 (같은 배치를 다시 내보낸 영수증은 추가하지 않음)
+func (s *ReceiptHistoryStore) Add(records []ReceiptHistoryRecord) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for _, record := range records {
+		if s.containsLocked(record) {
+			continue
+		}
+		s.records = append(s.records, record)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	s.pruneLocked()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return added, err
+	}
+	data, err := json.MarshalIndent(s.records, "", "  ")
+	if err != nil {
+		return added, err
+	}
+	tempPath := s.path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0o644); err != nil {
+		return added, err
+	}
+	return added, os.Rename(tempPath, s.path)
+}
+
+// 같은 배치에서 이미 기록된 영수증인지 확인 (배치 ID가 없는 기록은 항상 새로 추가)
+func (s *ReceiptHistoryStore) containsLocked(record ReceiptHistoryRecord) bool {
+	if record.BatchID == "" {
+		return false
+	}
+	for _, existing := range s.records {
+		if existing.BatchID == record.BatchID && existing.ImageHash == record.ImageHash && existing.ReceiptKey == record.ReceiptKey {
+			return true
+		}
+	}
+	return false
+}
+
+// 보관 기간이 지난 이력 삭제
+func (s *ReceiptHistoryStore) pruneLocked() {
+	retentionDays := getDuplicateHistoryDays()
+	if retentionDays <= 0 {
+		return
+	}
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+
+	kept := s.records[:0]
+	for _, record := range s.records {
+		if record.ClaimedAt.After(cutoff) {
+			kept = append(kept, record)
+		}
+	}
+	s.records = kept
+}
+
+// 영수증 의미 키 생성 (사용처 + 금액 + 사용일시, 하나라도 없으면 빈 값)
+func receiptKey(result OCRResult) string {
+	purpose := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, result.Purpose)
+	timestamp := strings.TrimSpace(result.OriginalIssueDate)
+
+	if purpose == "" || result.Amount.IsZero() || timestamp == "" {
+		return ""
+	}
+	return purpose + "|" + result.Amount.String() + "|" + timestamp
+}
+
+// 먼저 처리된 결과와 이력을 기준으로 중복 여부 확인
+func detectDuplicateReceipt(result OCRResult, earlier []OCRResult) *DuplicateInfo {
+	key := receiptKey(result)
+
+	// 같은 업로드 내 중복 (이미지 해시 우선)
+	for _, previous := range earlier {
+		if result.ImageHash != "" && previous.ImageHash == result.ImageHash {
+			return &DuplicateInfo{Type: DUPLICATE_TYPE_IMAGE, Scope: DUPLICATE_SCOPE_BATCH, ReferenceFileName: previous.FileName}
+		}
+	}
+	for _, previous := range earlier {
+		if key != "" && receiptKey(previous) == key {
+			return &DuplicateInfo{Type: DUPLICATE_TYPE_RECEIPT, Scope: DUPLICATE_SCOPE_BATCH, ReferenceFileName: previous.FileName}
+		}
+	}
+
+	// 이전에 내보낸 내역과 중복 (같은 배치를 다시 내보내는 경우 자기 자신과 비교하지 않음)
+	if record, duplicateType := getReceiptHistoryStore().Find(result.ImageHash, key, result.BatchID); record != nil {
+		claimedAt := record.ClaimedAt
+		return &DuplicateInfo{
+			Type:               duplicateType,
+			Scope:              DUPLICATE_SCOPE_HISTORY,
+			ReferenceFileName:  record.FileName,
+			ReferenceUserName:  record.UserName,
+			ReferenceClaimedAt: &claimedAt,
+		}
+	}
+	return nil
+}
+
+// 결과 목록의 중복 의심 항목 표시 (앞선 항목을 원본으로 간주)
+func markDuplicateReceipts(results []OCRResult) {
+	for i := range results {
+		results[i].Duplicate = detectDuplicateReceipt(results[i], results[:i])
+		if results[i].Duplicate != nil {
+			log.Printf("⚠️ 중복 의심 영수증: %s → %s (%s, %s)", results[i].FileName,
+				results[i].Duplicate.ReferenceFileName, results[i].Duplicate.Type, results[i].Duplicate.Scope)
+		}
+	}
+}
+
+// 내보낸 결과를 영수증 이력에 기록
+func recordExportedReceipts(results []OCRResult, userName string) {
+	claimedAt := time.Now()
+	records := make([]ReceiptHistoryRecord, 0, len(results))
+	for _, result := range results {
+		records = append(records, ReceiptHistoryRecord{
+			BatchID:    result.BatchID,
+			ImageHash:  result.ImageHash,
+			ReceiptKey: receiptKey(result),
+			FileName:   result.FileName,
+			UserName:   userName,
+			ClaimedAt:  claimedAt,
+		})
+	}
+
+	added, err := getReceiptHistoryStore().Add(records)
+	if err != nil {
+		log.Printf("⚠️ 영수증 이력 저장 실패: %v", err)
+		return
+	}
+	if added < len(records) {
+		log.Printf("영수증 이력: %d건 추가 (같은 배치에서 이미 기록된 %d건 제외)", added, len(records)-added)
+	}
+}
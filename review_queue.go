@@ -0,0 +1,465 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 검토 상태
+const (
+	REVIEW_STATUS_PENDING   = "pending"   // 검토 대기
+	REVIEW_STATUS_CONFIRMED = "confirmed" // OCR 값 그대로 확인
+	REVIEW_STATUS_CORRECTED = "corrected" // 검토자가 값 수정
+)
+
+// Excel 내보내기 시 미검토 항목 처리 정책
+const (
+	REVIEW_POLICY_OFF   = "off"
+	REVIEW_POLICY_WARN  = "warn"
+	REVIEW_POLICY_BLOCK = "block"
+)
+
+// 신뢰도를 확인하는 필드 (Excel에 들어가는 값)
+var REVIEW_FIELDS = []string{"사용처", "사용액", "공급가", "부가세", "사용일"}
+
+var errReviewNotFound = errors.New("검토 항목을 찾을 수 없습니다")
+
+// ReviewField 검토 항목의 필드별 OCR 값과 신뢰도
+type ReviewField struct {
+	Name        string  `json:"name"`
+	Value       string  `json:"value"` // OCR 인식 값
+	Confidence  float64 `json:"confidence"`
+	NeedsReview bool    `json:"needsReview"`         // 신뢰도 기준 미만
+	Corrected   *string `json:"corrected,omitempty"` // 검토자 수정 값 (수정하지 않았으면 nil)
+}
+
+// ReviewItem 신뢰도가 낮은 필드가 있는 OCR 결과 (업로드 배치와 무관하게 보관)
+type ReviewItem struct {
+	ID         string        `json:"id"`
+	Status     string        `json:"status"`
+	FileName   string        `json:"fileName"`
+	ImageHash  string        `json:"imageHash,omitempty"`
+	UserName   string        `json:"userName"`
+	Fields     []ReviewField `json:"fields"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	ReviewedAt *time.Time    `json:"reviewedAt,omitempty"`
+	Reviewer   string        `json:"reviewer,omitempty"`
+
+	// 처음 등록한 업로드의 사용일 추론 기준 (수정 값 검증과 반영에 같은 기준 사용)
+	UploadedAt       *time.Time `json:"uploadedAt,omitempty"`
+	ClaimPeriodStart string     `json:"claimPeriodStart,omitempty"`
+	ClaimPeriodEnd   string     `json:"claimPeriodEnd,omitempty"`
+}
+
+// ReviewRequest 검토 확인/수정 요청 (fields: 필드명 → 수정 값)
+type ReviewRequest struct {
+	Reviewer string            `json:"reviewer"`
+	Fields   map[string]string `json:"fields"`
+}
+
+// ReviewStore 파일 기반 검토 대기열
+type ReviewStore struct {
+	mu    sync.RWMutex
+	path  string
+	items []ReviewItem
+}
+
+var (
+	reviewStore     *ReviewStore
+	reviewStoreOnce sync.Once
+)
+
+// 전역 검토 대기열 반환 (최초 호출 시 파일에서 로드)
+func getReviewStore() *ReviewStore {
+	reviewStoreOnce.Do(func() {
+		reviewStore = NewReviewStore(getReviewQueueFile())
+	})
+	return reviewStore
+}
+
+// 검토 대기열 생성자
+func NewReviewStore(path string) *ReviewStore {
+	store := &ReviewStore{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("⚠️ 검토 대기열 파일 읽기 실패 (%s): %v", path, err)
+		}
+		return store
+	}
+	if err := json.Unmarshal(data, &store.items); err != nil {
+		log.Printf("⚠️ 검토 대기열 파일 파싱 실패 (%s): %v", path, err)
+		store.items = nil
+	}
+	store.pruneLocked()
+	log.Printf("검토 대기열 로드: %d건 (%s)", len(store.items), path)
+	return store
+}
+
+// 검토 항목 추가 (같은 이미지가 이미 있으면 기존 항목 반환)
+func (s *ReviewStore) Enqueue(item ReviewItem) (ReviewItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item.ImageHash != "" {
+		for _, existing := range s.items {
+			if existing.ImageHash == item.ImageHash {
+				return existing, nil
+			}
+		}
+	}
+
+	item.ID = uuid.New().String()
+	item.Status = REVIEW_STATUS_PENDING
+	item.CreatedAt = time.Now()
+	s.items = append(s.items, item)
+	s.pruneLocked()
+	return item, s.saveLocked()
+}
+
+// 상태별 검토 항목 목록 (status가 비어 있으면 전체, 오래된 순)
+func (s *ReviewStore) List(status string) []ReviewItem {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := []ReviewItem{}
+	for _, item := range s.items {
+		if status == "" || item.Status == status {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+	return items
+}
+
+// ID 또는 이미지 해시로 검토 항목 조회
+func (s *ReviewStore) Find(id, imageHash string) (ReviewItem, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, item := range s.items {
+		if (id != "" && item.ID == id) || (imageHash != "" && item.ImageHash == imageHash) {
+			return item, true
+		}
+	}
+	return ReviewItem{}, false
+}
+
+// 검토 결과 기록 (수정 값이 OCR 값과 다르면 corrected, 아니면 confirmed)
+func (s *ReviewStore) Review(id string, request ReviewRequest) (ReviewItem, error) {
+	reviewer := strings.TrimSpace(request.Reviewer)
+	if reviewer == "" {
+		return ReviewItem{}, fmt.Errorf("검토자(reviewer)를 입력해주세요")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := -1
+	for i := range s.items {
+		if s.items[i].ID == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return ReviewItem{}, errReviewNotFound
+	}
+
+	// 수정 값 검증 후 반영 (검증 실패 시 항목은 그대로 유지)
+	item := s.items[index]
+	item.Fields = append([]ReviewField{}, item.Fields...)
+	for i := range item.Fields {
+		item.Fields[i].Corrected = nil
+	}
+	status := REVIEW_STATUS_CONFIRMED
+	for name, value := range request.Fields {
+		value = strings.TrimSpace(value)
+		if err := validateReviewValue(name, value, item.dateContext(DateTimeContext{})); err != nil {
+			return ReviewItem{}, err
+		}
+
+		fieldIndex := -1
+		for i := range item.Fields {
+			if item.Fields[i].Name == name {
+				fieldIndex = i
+			}
+		}
+		if fieldIndex < 0 {
+			item.Fields = append(item.Fields, ReviewField{Name: name})
+			fieldIndex = len(item.Fields) - 1
+		}
+		if value != item.Fields[fieldIndex].Value {
+			item.Fields[fieldIndex].Corrected = &value
+			status = REVIEW_STATUS_CORRECTED
+		}
+	}
+
+	reviewedAt := time.Now()
+	item.Status = status
+	item.Reviewer = reviewer
+	item.ReviewedAt = &reviewedAt
+	s.items[index] = item
+	s.pruneLocked()
+	return item, s.saveLocked()
+}
+
+// 보관 기간이 지난 검토 완료 항목 삭제 (검토 대기 항목은 유지)
+func (s *ReviewStore) pruneLocked() {
+	retentionDays := getReviewRetentionDays()
+	if retentionDays <= 0 {
+		return
+	}
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+
+	kept := s.items[:0]
+	for _, item := range s.items {
+		if item.Status == REVIEW_STATUS_PENDING || item.ReviewedAt == nil || item.ReviewedAt.After(cutoff) {
+			kept = append(kept, item)
+		}
+	}
+	s.items = kept
+}
+
+// 대기열을 파일에 저장 (임시 파일에 쓴 뒤 이름 변경)
+func (s *ReviewStore) saveLocked() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.items, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempPath := s.path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, s.path)
+}
+
+// 수정 값 형식 확인 (금액은 금액 형식, 사용일은 업로드 기준으로 인식 가능해야 함)
+func validateReviewValue(name, value string, dateContext DateTimeContext) error {
+	switch name {
+	case "사용처":
+		if value == "" {
+			return fmt.Errorf("사용처를 비울 수 없습니다")
+		}
+	case "사용액", "공급가", "부가세":
+		if _, err := ParseMoney(value); err != nil {
+			return fmt.Errorf("%s 형식 오류 (%q): %v", name, value, err)
+		}
+	case "사용일":
+		if _, err := ParseDateTimeWithContext(value, dateContext); err != nil {
+			return fmt.Errorf("사용일 형식 오류 (%q): %v", value, err)
+		}
+	default:
+		return fmt.Errorf("검토할 수 없는 필드입니다: %s (가능한 필드: %s)", name, strings.Join(REVIEW_FIELDS, ", "))
+	}
+	return nil
+}
+
+// 항목을 등록한 업로드의 사용일 추론 기준 (기록이 없는 이전 항목은 fallback)
+func (item ReviewItem) dateContext(fallback DateTimeContext) DateTimeContext {
+	return uploadDateContext(item.UploadedAt, item.ClaimPeriodStart, item.ClaimPeriodEnd, fallback)
+}
+
+// 검토 항목의 수정 값 (필드명 → 수정 값)
+func (item ReviewItem) Corrections() map[string]string {
+	corrections := map[string]string{}
+	for _, field := range item.Fields {
+		if field.Corrected != nil {
+			corrections[field.Name] = *field.Corrected
+		}
+	}
+	return corrections
+}
+
+// OCR 필드 신뢰도 기록 및 기준 미만 필드를 검토 대기열에 등록
+// 같은 이미지를 이미 검토했으면 그 결과(수정 값)를 바로 반영합니다.
+func queueLowConfidenceFields(result *OCRResult, fields []Field, userName string, dateContext DateTimeContext) {
+	threshold := getReviewConfidenceThreshold()
+
+	var reviewFields []ReviewField
+	for _, name := range REVIEW_FIELDS {
+		for _, field := range fields {
+			if field.Name != name {
+				continue
+			}
+			if result.FieldConfidence == nil {
+				result.FieldConfidence = map[string]float64{}
+			}
+			result.FieldConfidence[name] = field.InferConfidence
+
+			needsReview := threshold > 0 && field.InferConfidence < threshold
+			if needsReview {
+				result.ReviewFields = append(result.ReviewFields, name)
+			}
+			reviewFields = append(reviewFields, ReviewField{
+				Name:        name,
+				Value:       field.InferText,
+				Confidence:  field.InferConfidence,
+				NeedsReview: needsReview,
+			})
+			break
+		}
+	}
+	if len(result.ReviewFields) == 0 {
+		return
+	}
+
+	item, err := getReviewStore().Enqueue(ReviewItem{
+		FileName:  result.FileName,
+		ImageHash: result.ImageHash,
+		UserName:  userName,
+		Fields:    reviewFields,
+
+		UploadedAt:       result.UploadedAt,
+		ClaimPeriodStart: result.ClaimPeriodStart,
+		ClaimPeriodEnd:   result.ClaimPeriodEnd,
+	})
+	if err != nil {
+		log.Printf("⚠️ 검토 대기열 저장 실패: %v - 파일: %s", err, result.FileName)
+	}
+	if item.ID == "" {
+		return
+	}
+	result.ReviewID = item.ID
+	result.ReviewStatus = item.Status
+	log.Printf("🔍 검토 필요: %s (신뢰도 %.2f 미만: %s, 상태: %s)", result.FileName, threshold, strings.Join(result.ReviewFields, ", "), item.Status)
+	if item.Status == REVIEW_STATUS_CORRECTED {
+		applyReviewCorrections(result, item.Corrections(), item.dateContext(dateContext))
+	}
+}
+
+// 검토자 수정 값을 결과에 반영 (금액/사용일이 바뀌면 부가세 분리와 외화 환산 다시 계산)
+// 수정한 사용일은 dateContext(업로드/다운로드 일시, 청구 기간) 기준으로 연도를 추론합니다.
+func applyReviewCorrections(result *OCRResult, corrections map[string]string, dateContext DateTimeContext) {
+	if len(corrections) == 0 {
+		return
+	}
+
+	if value, ok := corrections["사용처"]; ok {
+		result.Purpose = value
+	}
+	_, dateChanged := corrections["사용일"]
+	if dateChanged {
+		if parsed, err := ParseDateTimeWithContext(corrections["사용일"], dateContext); err == nil {
+			result.OriginalIssueDate = parsed.Standard()
+			result.IssueDate = parsed.YYYYMMDD()
+			result.IssueDateParse = parsed.Info()
+		}
+	}
+
+	correctedAmount := func(name string) (Money, bool) {
+		value, ok := corrections[name]
+		if !ok {
+			return 0, false
+		}
+		amount, err := ParseMoney(value)
+		return amount, err == nil
+	}
+	amount, amountChanged := correctedAmount("사용액")
+	supply, supplyChanged := correctedAmount("공급가")
+	vat, vatChanged := correctedAmount("부가세")
+
+	// 외화 영수증은 원금액 기준으로 다시 환산
+	if result.Currency != "" {
+		if !amountChanged {
+			amount = result.OriginalAmount
+		}
+		if amountChanged || dateChanged {
+			result.Amount = amount
+			applyCurrencyConversion(result, result.Currency)
+		}
+		return
+	}
+
+	if amountChanged || supplyChanged || vatChanged {
+		if !amountChanged {
+			amount = result.Amount
+		}
+		split := splitVAT(amount, supply, vat, result.Purpose)
+		result.Amount, result.SupplyAmount, result.VATAmount, result.TaxExempt = split.Total, split.Supply, split.VAT, split.TaxExempt
+	}
+}
+
+// 내보낼 결과에 검토 상태와 수정 값 반영 (클라이언트가 보낸 상태는 신뢰하지 않고 대기열 기준)
+// 수정 값은 사용자가 고치지 않은 필드에만 반영하며, 검토 대기 중인 결과 목록을 반환합니다.
+// 수정한 사용일은 검토 항목을 등록한 업로드의 일시/청구 기간 기준으로 추론합니다 (없으면 결과 또는 fallback 기준).
+func applyReviewResults(results []OCRResult, fallback DateTimeContext) []OCRResult {
+	pending := []OCRResult{}
+	store := getReviewStore()
+	for i := range results {
+		item, exists := store.Find(results[i].ReviewID, results[i].ImageHash)
+		if !exists {
+			continue
+		}
+		results[i].ReviewID = item.ID
+		results[i].ReviewStatus = item.Status
+		switch item.Status {
+		case REVIEW_STATUS_PENDING:
+			pending = append(pending, results[i])
+		case REVIEW_STATUS_CORRECTED:
+			dateContext := item.dateContext(resultDateContext(results[i], fallback))
+			applyReviewCorrections(&results[i], unchangedReviewCorrections(results[i], item, dateContext), dateContext)
+		}
+	}
+	return pending
+}
+
+// 내보낼 값이 아직 OCR 원래 값과 같은 필드의 수정 값만 반환
+// 업로드 후 사용자가 직접 고친 값이나 이미 수정 값이 반영된 필드는 덮어쓰지 않습니다.
+func unchangedReviewCorrections(result OCRResult, item ReviewItem, dateContext DateTimeContext) map[string]string {
+	// 검토 항목의 OCR 값으로 업로드 당시 변환 결과를 다시 계산
+	fields := make([]Field, 0, len(item.Fields))
+	for _, field := range item.Fields {
+		fields = append(fields, Field{Name: field.Name, InferText: field.Value})
+	}
+	ocrService := currentOCRService()
+	originalAmount := ocrService.CalculateAmount(fields)
+	originalSplit := ocrService.SplitVAT(fields)
+
+	corrections := map[string]string{}
+	for name, value := range item.Corrections() {
+		var unchanged bool
+		switch name {
+		case "사용처":
+			unchanged = strings.TrimSpace(result.Purpose) == strings.TrimSpace(ocrService.ExtractFieldValue(fields, name))
+		case "사용액":
+			amount := result.Amount
+			if result.Currency != "" {
+				amount = result.OriginalAmount
+			}
+			unchanged = amount == originalAmount
+		case "공급가":
+			unchanged = result.Currency != "" || result.SupplyAmount == originalSplit.Supply
+		case "부가세":
+			unchanged = result.Currency != "" || result.VATAmount == originalSplit.VAT
+		case "사용일":
+			originalDate := ocrService.ExtractFieldValue(fields, name)
+			if parsed, err := ParseDateTimeWithContext(originalDate, dateContext); err == nil {
+				originalDate = parsed.YYYYMMDD()
+			}
+			unchanged = result.IssueDate == originalDate
+		}
+		if !unchanged {
+			log.Printf("검토 수정 값 미적용 (내보낼 %s 값이 OCR 값과 다름): %s - 파일: %s", name, value, result.FileName)
+			continue
+		}
+		corrections[name] = value
+	}
+	return corrections
+}
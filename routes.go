@@ -2,6 +2,8 @@ package main
 
 import (
 	"log"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -17,18 +19,124 @@ func setupRoutes(app *fiber.App) {
 		return handleOCRProcess(c)
 	})
 
+	// 비동기 OCR 작업 엔드포인트 (제출 → 상태 조회/취소)
+	api.Post("/jobs", func(c *fiber.Ctx) error {
+		log.Printf("📦 /api/jobs 엔드포인트 호출됨")
+		return handleOCRJobSubmit(c)
+	})
+	api.Get("/jobs/:id", handleOCRJobStatus)
+	api.Get("/jobs/:id/events", handleOCRJobEvents)
+	api.Delete("/jobs/:id", handleOCRJobCancel)
+
 	// Excel 다운로드 엔드포인트
 	api.Post("/download-excel", func(c *fiber.Ctx) error {
 		log.Printf("📄 /api/download-excel 엔드포인트 호출됨")
 		return handleExcelDownload(c)
 	})
 
+	// 신뢰도 검토 대기열 엔드포인트 (조회 → 확인/수정)
+	api.Get("/review", handleReviewList)
+	api.Post("/review/:id", handleReviewSubmit)
+
+	// OCR 작업 풀 통계 엔드포인트
+	api.Get("/ocr/stats", func(c *fiber.Ctx) error {
+		return c.JSON(getOCRWorkerPool().Stats())
+	})
+
+	// 카테고리 목록 조회 (프론트엔드 선택 목록, 필수 입력, 비고 템플릿)
+	api.Get("/categories", func(c *fiber.Ctx) error {
+		catalog := currentCategoryCatalog()
+		return c.JSON(fiber.Map{
+			"default":    catalog.DefaultCode(),
+			"categories": catalog.All(),
+		})
+	})
+
+	// 현재 적용 중인 카테고리 규칙 조회
+	api.Get("/category-rules", func(c *fiber.Ctx) error {
+		engine, err := getCategoryRuleEngine()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.JSON(fiber.Map{
+			"enabled": isTimeCategoryEnabled(),
+			"source":  engine.source,
+			"rules":   engine.Rules(),
+		})
+	})
+
+	// 날짜 패턴 목록 조회 (우선순위, 그룹 인덱스, 샘플)
+	api.Get("/datetime-patterns", func(c *fiber.Ctx) error {
+		set := currentDateTimePatternSet()
+		return c.JSON(fiber.Map{
+			"source":   set.source,
+			"patterns": set.Definitions(),
+		})
+	})
+
+	// 문자열을 모든 날짜 패턴에 적용한 결과 (새 영수증 형식 확인용)
+	api.Get("/datetime-patterns/test", func(c *fiber.Ctx) error {
+		text := c.Query("text")
+		if strings.TrimSpace(text) == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "text 파라미터가 필요합니다",
+			})
+		}
+
+		// 업로드일(reference)과 청구 기간은 선택 (기본: 현재 시각, 기간 없음)
+		reference := time.Now()
+		if value := c.Query("reference"); value != "" {
+			date, err := parseDateInput(value)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "reference 형식 오류 (YYYY-MM-DD)",
+				})
+			}
+			reference = date.Add(24*time.Hour - time.Second)
+		}
+		ctx, err := NewDateTimeContext(reference, c.Query("claim_period_start"), c.Query("claim_period_end"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		set := currentDateTimePatternSet()
+		response := fiber.Map{
+			"text":     text,
+			"patterns": set.Test(text, ctx),
+		}
+		if parsed, err := set.Parse(text, ctx); err == nil {
+			response["selected"] = fiber.Map{
+				"pattern":    parsed.Pattern,
+				"result":     parsed.Standard(),
+				"yyyymmdd":   parsed.YYYYMMDD(),
+				"components": parsed.Components,
+				"inferred":   parsed.Inferred,
+				"confidence": parsed.Confidence,
+				"guessed":    parsed.Guessed(),
+			}
+		} else {
+			response["error"] = err.Error()
+		}
+		return c.JSON(response)
+	})
+
+	// OCR 결과 캐시 통계 엔드포인트
+	api.Get("/ocr/cache", func(c *fiber.Ctx) error {
+		return c.JSON(getOCRCacheStats())
+	})
+
 	// 헬스 체크 엔드포인트
 	api.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
-			"status":  "ok",
-			"message": "OCR to Excel API is running",
-			"version": "2.0",
+			"status":    "ok",
+			"message":   "OCR to Excel API is running",
+			"version":   "2.0",
+			"ocr_pool":  getOCRWorkerPool().Stats(),
+			"ocr_cache": getOCRCacheStats(),
 			"flow": map[string]interface{}{
 				"two_step_process": map[string]string{
 					"step1": "POST /api/process-ocr",
@@ -38,6 +146,16 @@ func setupRoutes(app *fiber.App) {
 		})
 	})
 
+	// 내장 Mock OCR 엔드포인트 (MOCK_OCR_ENABLED=true인 경우에만)
+	if isMockOCREnabled() {
+		mockServer, err := NewMockOCRServer(getMockOCRFixtureDir())
+		if err != nil {
+			log.Fatalf("Mock OCR 서버 초기화 실패: %v", err)
+		}
+		app.Post(MOCK_OCR_PATH, mockServer.handleInfer)
+		log.Printf("🧪 Mock OCR 엔드포인트 활성화: POST %s", MOCK_OCR_PATH)
+	}
+
 	// 정적 파일 제공 (CSS, JS, 이미지 등)
 	app.Static("/", "./static")
 
@@ -59,12 +177,33 @@ func setupRoutes(app *fiber.App) {
 					"description": "이미지 OCR 처리 후 JSON 결과 반환",
 					"params": []string{
 						"user_name (required)",
-						"images[] (required)",
+						"images[] (required, 형식은 파일 내용으로 판별, HEIC/WebP는 JPEG/PNG로 변환, 다중 페이지 PDF/TIFF는 페이지별 결과, MAX_FILES는 페이지 수 기준)",
 						"category_N (optional)",
+						"claim_period_start, claim_period_end (optional, YYYY-MM-DD - 연도 없는 사용일 추론 기준)",
 						"remarks_N (optional)",
 						"depositor_dc, dept_cd, emp_cd, bank_cd, ba_nb (optional)",
 					},
 				},
+				"submit_job": map[string]interface{}{
+					"method":      "POST",
+					"path":        "/api/jobs",
+					"description": "OCR 작업 제출 후 작업 ID 즉시 반환 (파라미터는 process_ocr과 동일)",
+				},
+				"job_status": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/jobs/{id}",
+					"description": "이미지별 진행 상황과 부분 OCR 결과 조회",
+				},
+				"job_events": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/jobs/{id}/events",
+					"description": "이미지별 시작/완료/실패/카테고리 조정 이벤트 스트림 (SSE)",
+				},
+				"cancel_job": map[string]interface{}{
+					"method":      "DELETE",
+					"path":        "/api/jobs/{id}",
+					"description": "진행 중인 OCR 작업 취소",
+				},
 				"download_excel": map[string]interface{}{
 					"method":      "POST",
 					"path":        "/api/download-excel",
@@ -72,22 +211,57 @@ func setupRoutes(app *fiber.App) {
 					"params": []string{
 						"excel_data (required JSON string)",
 						"user_name, depositor_dc, dept_cd, emp_cd, bank_cd, ba_nb (optional)",
+						"allow_unreviewed (optional, REVIEW_EXPORT_POLICY=block일 때 검토 대기 항목 포함 허용)",
 					},
 				},
+				"review_list": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/review?status=pending",
+					"description": "OCR 신뢰도가 REVIEW_CONFIDENCE_THRESHOLD 미만인 항목 목록 (배치 구분 없음, status: pending | confirmed | corrected | all)",
+				},
+				"review_submit": map[string]interface{}{
+					"method":      "POST",
+					"path":        "/api/review/{id}",
+					"description": "검토 확인/수정 (JSON: reviewer, fields{필드명: 수정 값} - fields가 없으면 OCR 값 확인)",
+				},
 				"health": map[string]interface{}{
 					"method":      "GET",
 					"path":        "/api/health",
 					"description": "서버 상태 확인",
 				},
+				"ocr_stats": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/ocr/stats",
+					"description": "OCR 작업 풀 대기열/대기 시간 통계",
+				},
+				"categories": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/categories",
+					"description": "카테고리 목록 (코드, 라벨, 필수 입력, 비고 템플릿, 사용 여부)",
+				},
+				"category_rules": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/category-rules",
+					"description": "카테고리 자동 조정 규칙 조회 (CATEGORY_RULES_FILE 변경 시 자동 반영)",
+				},
+				"datetime_patterns": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/datetime-patterns",
+					"description": "날짜/시간 패턴 목록 (DATETIME_PATTERNS_FILE 병합 결과)",
+				},
+				"datetime_patterns_test": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/datetime-patterns/test?text=...",
+					"description": "문자열을 모든 날짜 패턴에 적용해 일치 여부와 파싱 결과 확인 (reference, claim_period_start, claim_period_end 선택)",
+				},
+				"ocr_cache": map[string]interface{}{
+					"method":      "GET",
+					"path":        "/api/ocr/cache",
+					"description": "OCR 결과 캐시 적중/미스 통계",
+				},
 			},
 			"supported_formats": SUPPORTED_IMAGE_FORMATS,
-			"categories": map[string]string{
-				"6110": "조식",
-				"6120": "중식",
-				"6130": "석식",
-				"6310": "교통정산",
-				"6320": "국내출장",
-			},
+			"categories":        currentCategoryCatalog().ActiveLabels(),
 		})
 	})
 }
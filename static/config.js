@@ -7,12 +7,14 @@ const CONFIG = {
     MAX_FILE_SIZE_MB: 50,
     
     // 지원 파일 형식
-    SUPPORTED_FILE_TYPES: ['image/jpeg', 'image/jpg', 'image/png', 'image/tiff', 'image/tif', 'application/pdf'],
-    SUPPORTED_EXTENSIONS: ['.jpg', '.jpeg', '.png', '.pdf', '.tif', '.tiff'],
+    SUPPORTED_FILE_TYPES: ['image/jpeg', 'image/jpg', 'image/png', 'image/tiff', 'image/tif', 'application/pdf', 'image/heic', 'image/heif', 'image/webp'],
+    SUPPORTED_EXTENSIONS: ['.jpg', '.jpeg', '.png', '.pdf', '.tif', '.tiff', '.heic', '.heif', '.webp'],
     
     // API 엔드포인트
     API: {
         PROCESS_OCR: '/api/process-ocr',
+        JOBS: '/api/jobs',
+        CATEGORIES: '/api/categories',
         DOWNLOAD_EXCEL: '/api/download-excel'
     },
     
@@ -23,8 +25,8 @@ const CONFIG = {
     }
 };
 
-// 카테고리 옵션
-const CATEGORY_OPTIONS = [
+// 카테고리 옵션 (서버 /api/categories 응답으로 교체, 아래는 연결 실패 시 기본값)
+let CATEGORY_OPTIONS = [
     { label: '조식', value: '6110' },
     { label: '중식', value: '6120' },
     { label: '석식', value: '6130' },
@@ -33,11 +35,20 @@ const CATEGORY_OPTIONS = [
 ];
 
 // 폼 필드 정의
-const FORM_FIELDS = ['user_name', 'attr_cd', 'depositor_dc', 'dept_cd', 'emp_cd', 'bank_cd', 'ba_nb'];
+const FORM_FIELDS = ['user_name', 'attr_cd', 'depositor_dc', 'dept_cd', 'emp_cd', 'bank_cd', 'ba_nb', 'claim_period_start', 'claim_period_end'];
 const REQUIRED_FIELDS = ['user_name'];
 
+// 사용일 구성 요소 표시 이름 (추론 항목 표시용)
+const DATE_COMPONENT_LABELS = { year: '연도', month: '월', day: '일', hour: '시', minute: '분' };
+
+// 카테고리 정의 (필수 입력, 비고 템플릿 등 - 서버에서 로드)
+let CATEGORY_DEFINITIONS = {
+    '6320': { requiredFields: ['business_content', 'purpose'], remarkTemplate: '{business_content}_{names}_{purpose}' }
+};
+let DEFAULT_CATEGORY = '6130';
+
 // 키워드 기반 카테고리 매핑
-const CATEGORY_KEYWORDS = {
+let CATEGORY_KEYWORDS = {
     '6320': ['국내출장', '출장'],
     //'6310': ['교통', '택시', '지하철', '버스'],
     '6310': ['교통', '택시', '지하철'],
@@ -150,7 +150,7 @@ const FileManager = {
         };
         
         // 국내출장인 경우 파일명에서 용도 자동 추출
-        if (category === '6320') {
+        if (CategoryUtils.requiresBusinessInfo(category)) {
             fileData.purpose = FilenameUtils.extractPurposeForBusinessTrip(file.name);
         }
         
@@ -217,7 +217,7 @@ const FileManager = {
         fileItem.appendChild(categorySection);
         
         // 카테고리별 추가 필드들
-        if (fileData.category === '6320') {
+        if (CategoryUtils.requiresBusinessInfo(fileData.category)) {
             // 국내출장: 출장내용, 추가이름, 용도
             fileItem.appendChild(this._createBusinessContentSection(fileData, index, isAdditional));
             fileItem.appendChild(this._createAdditionalNamesSection(fileData, index, isAdditional));
@@ -248,7 +248,7 @@ const FileManager = {
         fileSize.textContent = UTILS.formatFileSize(fileData.file.size);
         
         // 자동 감지 정보 표시 (기본값이 아닌 경우)
-        if (fileData.category !== '6130') {
+        if (fileData.category !== CategoryUtils.getDefault()) {
             const autoInfo = document.createElement('div');
             autoInfo.className = 'auto-detected-info';
             autoInfo.textContent = `🤖 자동 감지: ${CategoryUtils.getLabel(fileData.category)}`;
@@ -444,7 +444,7 @@ const FileManager = {
         fileArray[index].category = newCategory;
         
         // 국내출장으로 변경 시 파일명에서 용도 자동 추출
-        if (newCategory === '6320') {
+        if (CategoryUtils.requiresBusinessInfo(newCategory)) {
             const purpose = FilenameUtils.extractPurposeForBusinessTrip(fileArray[index].file.name);
             fileArray[index].purpose = purpose;
         }
@@ -479,7 +479,7 @@ const FileManager = {
         const fileArray = this._getCurrentFileArray(isAdditional ? 'additional' : 'basic');
         const fileData = fileArray[index];
         
-        if (fileData.category === '6320') {
+        if (CategoryUtils.requiresBusinessInfo(fileData.category)) {
             this._updateBusinessTripRemark(index, isAdditional);
             return;
         }
@@ -19,13 +19,16 @@ const App = {
     },
     
     // 초기화 실행
-    _initialize() {
+    async _initialize() {
         console.log('애플리케이션 초기화 시작');
         
         try {
             // 전역 추가 파일 배열 초기화
             window.additionalSelectedFiles = [];
             
+            // 0. 서버 카테고리 목록 불러오기
+            await CategoryUtils.load();
+            
             // 1. 저장된 데이터 불러오기
             StorageManager.loadFormData();
             
@@ -218,7 +221,7 @@ const App = {
         fileArray.forEach((fileData, index) => {
             const userName = UTILS.getFormValue('user_name');
             
-            if (fileData.category === '6320') {
+            if (CategoryUtils.requiresBusinessInfo(fileData.category)) {
                 // 국내출장은 출장내용과 용도가 있을 때만 업데이트
                 if (fileData.businessContent && fileData.purpose) {
                     const newRemark = RemarkUtils.generateBusinessTrip(
@@ -160,7 +160,7 @@ const OCRProcessor = {
             // input에서 파일 가져와서 기본 구조로 변환
             filesToUpload = Array.from(imagesInput.files).map(file => ({
                 file: file,
-                category: '6130', // 기본값: 석식
+                category: CategoryUtils.getDefault(),
                 additionalNames: '',
                 remarks: '',
                 businessContent: '',
@@ -192,7 +192,7 @@ const OCRProcessor = {
             formData.append(`additional_names_${index}`, fileData.additionalNames || '');
             
             // 국내출장 전용 필드들
-            if (fileData.category === '6320') {
+            if (CategoryUtils.requiresBusinessInfo(fileData.category)) {
                 formData.append(`business_content_${index}`, fileData.businessContent || '');
                 formData.append(`purpose_${index}`, fileData.purpose || '');
             }
@@ -221,7 +221,7 @@ const OCRProcessor = {
             formData.append(`additional_names_${index}`, fileData.additionalNames || '');
             
             // 국내출장 전용 필드들
-            if (fileData.category === '6320') {
+            if (CategoryUtils.requiresBusinessInfo(fileData.category)) {
                 formData.append(`business_content_${index}`, fileData.businessContent || '');
                 formData.append(`purpose_${index}`, fileData.purpose || '');
             }
@@ -230,11 +230,11 @@ const OCRProcessor = {
         return formData;
     },
     
-    // OCR API 요청 전송
+    // OCR 작업 제출 후 진행 이벤트 스트림으로 결과 수신
     async _sendOCRRequest(formData) {
-        console.log('OCR API 요청 전송:', CONFIG.API.PROCESS_OCR);
+        console.log('OCR 작업 제출:', CONFIG.API.JOBS);
         
-        const response = await fetch(CONFIG.API.PROCESS_OCR, {
+        const response = await fetch(CONFIG.API.JOBS, {
             method: 'POST',
             body: formData
         });
@@ -246,7 +246,84 @@ const OCRProcessor = {
             throw new Error(errorData.error || `HTTP ${response.status} 오류`);
         }
         
-        return await response.json();
+        const submitted = await response.json();
+        return await this._watchJobProgress(submitted.jobId);
+    },
+    
+    // 작업 진행 이벤트(SSE) 구독
+    _watchJobProgress(jobId) {
+        return new Promise((resolve, reject) => {
+            const source = new EventSource(`${CONFIG.API.JOBS}/${jobId}/events`);
+            
+            const updateProgress = (data, text) => {
+                this._setProgressText(`(${data.processed}/${data.total}) ${text}`);
+            };
+            
+            source.addEventListener('started', (e) => {
+                const data = JSON.parse(e.data);
+                updateProgress(data, `${data.fileName} 처리 중...`);
+            });
+            
+            source.addEventListener('completed', (e) => {
+                const data = JSON.parse(e.data);
+                console.log('이미지 처리 완료:', data.fileName, data.results);
+                updateProgress(data, `✅ ${data.fileName} 완료`);
+            });
+            
+            source.addEventListener('failed', (e) => {
+                const data = JSON.parse(e.data);
+                console.warn('이미지 처리 실패:', data.fileName, data.errorCategory, data.error);
+                updateProgress(data, `❌ ${data.fileName} 실패`);
+            });
+            
+            source.addEventListener('category_adjusted', (e) => {
+                const data = JSON.parse(e.data);
+                console.log(`카테고리 자동 조정: ${data.fileName} ${CategoryUtils.getLabel(data.originalCategory)} → ${CategoryUtils.getLabel(data.category)}`);
+                const reason = data.categoryRule ? ` (${data.categoryRule.explanation})` : '';
+                updateProgress(data, `⏰ ${data.fileName}: ${CategoryUtils.getLabel(data.category)}(으)로 조정${reason}`);
+            });
+            
+            source.addEventListener('done', (e) => {
+                source.close();
+                const job = JSON.parse(e.data);
+                if (job.status === 'completed') {
+                    resolve({ success: true, message: job.message, data: job.data, failures: job.failures });
+                } else {
+                    const details = this._formatFailures(job.failures);
+                    reject(new Error(details ? `${job.message} — ${details}` : job.message));
+                }
+            });
+            
+            source.onerror = () => {
+                source.close();
+                reject(new Error('진행 상황 연결이 끊어졌습니다. 잠시 후 다시 시도해주세요.'));
+            };
+        });
+    },
+    
+    // 실패 파일 안내 문구 생성
+    _formatFailures(failures) {
+        if (!failures || failures.length === 0) {
+            return '';
+        }
+        return failures.map(f => `${f.fileName}: ${f.suggestedAction}`).join(' / ');
+    },
+    
+    // 결과 메시지 표시 (실패 파일이 있으면 경고로 표시)
+    _showResultMessage(successText, failures) {
+        if (failures && failures.length > 0) {
+            UIUtils.showError(`⚠️ ${successText} 단, ${failures.length}개 파일은 처리하지 못했습니다 — ${this._formatFailures(failures)}`);
+        } else {
+            UIUtils.showSuccess(`✅ ${successText}`);
+        }
+    },
+    
+    // 로딩 메시지 갱신
+    _setProgressText(text) {
+        const loadingText = document.getElementById('loadingText');
+        if (loadingText) {
+            loadingText.textContent = text;
+        }
     },
     
     // 성공 처리 (기본)
@@ -257,7 +334,7 @@ const OCRProcessor = {
         ocrResults = result.data;
         
         // 성공 메시지 표시
-        UIUtils.showSuccess(`✅ ${result.data.length}개 파일의 OCR 처리가 완료되었습니다!`);
+        this._showResultMessage(`${result.data.length}개 파일의 OCR 처리가 완료되었습니다!`, result.failures);
         
         // 결과 화면으로 전환
         ResultsManager.showResults(result.data);
@@ -284,7 +361,7 @@ const OCRProcessor = {
         }
         
         // 성공 메시지 표시
-        UIUtils.showSuccess(`✅ ${result.data.length}개 추가 파일의 OCR 처리가 완료되었습니다! (총 ${ocrResults.length}개)`);
+        this._showResultMessage(`${result.data.length}개 추가 파일의 OCR 처리가 완료되었습니다! (총 ${ocrResults.length}개)`, result.failures);
         
         // 결과 테이블 업데이트
         ResultsManager.showResults(ocrResults);
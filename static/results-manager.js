@@ -49,6 +49,8 @@ const ResultsManager = {
             this._createRemarkCell(result, index),
             this._createPurposeCell(result, index),
             this._createAmountCell(result, index),
+            this._createSupplyAmountCell(result, index),
+            this._createVATAmountCell(result, index),
             this._createIssueDateCell(result, index),
             this._createPayDateCell(result)
         ];
@@ -62,10 +64,55 @@ const ResultsManager = {
     _createFileNameCell(result) {
         const cell = document.createElement('td');
         cell.className = 'file-name-cell';
-        cell.textContent = result.fileName;
+        cell.textContent = result.pageCount
+            ? `${result.sourceFile} (${result.page}/${result.pageCount}쪽)`
+            : result.fileName;
+        
+        // 중복 의심 표시
+        if (result.duplicate) {
+            const badge = document.createElement('span');
+            badge.className = 'duplicate-badge';
+            badge.textContent = ' ⚠️ 중복 의심';
+            badge.title = this._describeDuplicate(result.duplicate);
+            cell.appendChild(badge);
+        }
+        
+        // OCR 신뢰도가 낮은 필드 검토 표시
+        if (result.reviewFields && result.reviewFields.length > 0) {
+            const status = result.reviewStatus || 'pending';
+            const badge = document.createElement('div');
+            badge.className = `review-badge review-${status}`;
+            badge.textContent = status === 'pending'
+                ? `🔍 검토 필요: ${result.reviewFields.join(', ')}`
+                : `✅ 검토 완료: ${result.reviewFields.join(', ')}`;
+            badge.title = result.reviewFields
+                .map(name => `${name} 신뢰도 ${((result.fieldConfidence || {})[name] ?? 0).toFixed(2)}`)
+                .join(', ');
+            cell.appendChild(badge);
+        }
+        
+        // 경비 정책 위반 표시
+        (result.violations || []).forEach(violation => {
+            const badge = document.createElement('div');
+            badge.className = `policy-badge policy-${violation.severity}`;
+            badge.textContent = `${violation.severity === 'error' ? '⛔' : '⚠️'} ${violation.message}`;
+            cell.appendChild(badge);
+        });
         return cell;
     },
     
+    // 중복 의심 설명 문구
+    _describeDuplicate(duplicate) {
+        const typeLabel = duplicate.type === 'exact_image' ? '동일한 이미지' : '같은 사용처/금액/사용일시';
+        if (duplicate.scope === 'history') {
+            const claimedAt = duplicate.referenceClaimedAt
+                ? new Date(duplicate.referenceClaimedAt).toLocaleString('ko-KR')
+                : '';
+            return `${typeLabel} - 이전에 청구됨: ${duplicate.referenceFileName} (${duplicate.referenceUserName || '-'}, ${claimedAt})`;
+        }
+        return `${typeLabel} - 같은 업로드의 ${duplicate.referenceFileName}`;
+    },
+    
     // 카테고리 셀
     _createCategoryCell(result, index) {
         const cell = document.createElement('td');
@@ -81,6 +128,11 @@ const ResultsManager = {
             select.appendChild(optionElement);
         });
         
+        // 자동 조정 규칙 설명 표시
+        if (result.categoryRule) {
+            select.title = `자동 분류 규칙: ${result.categoryRule.explanation} (${result.categoryRule.ruleId})`;
+        }
+        
         select.addEventListener('change', (e) => {
             ocrResults[index].category = e.target.value;
             this._updateResultRemark(index);
@@ -102,6 +154,25 @@ const ResultsManager = {
         timeSpan.textContent = timeInfo || '-';
         
         cell.appendChild(timeSpan);
+        
+        // 원문에서 날짜를 읽지 못하고 추정한 경우 표시
+        const parse = result.issueDateParse;
+        if (parse && parse.guessed) {
+            const badge = document.createElement('div');
+            badge.className = 'date-guessed-badge';
+            badge.textContent = '⚠️ 날짜 추정';
+            badge.title = parse.pattern
+                ? `인식 패턴: ${parse.pattern}, 인식 항목: ${parse.components.join(', ')}, 신뢰도: ${parse.confidence}`
+                : '사용일을 인식하지 못했습니다';
+            cell.appendChild(badge);
+        } else if (parse && parse.inferred && parse.inferred.length > 0) {
+            // 연도/날짜를 업로드일 또는 청구 기간으로 추론한 경우 검토 표시
+            const badge = document.createElement('div');
+            badge.className = 'date-guessed-badge';
+            badge.textContent = `🔎 ${parse.inferred.map(c => DATE_COMPONENT_LABELS[c] || c).join('/')} 추론`;
+            badge.title = `인식 패턴: ${parse.pattern}, 원문에 없어 추론한 항목을 확인해주세요`;
+            cell.appendChild(badge);
+        }
         return cell;
     },
     
@@ -144,12 +215,76 @@ const ResultsManager = {
         
         input.addEventListener('input', (e) => {
             ocrResults[index].amount = e.target.value;
+            this._updateVATSplit(index);
+        });
+        
+        cell.appendChild(input);
+        
+        // 외화 영수증 원금액/환율 표시
+        if (result.currency) {
+            const original = document.createElement('div');
+            original.className = 'currency-original';
+            original.textContent = result.fxRate
+                ? `${result.currency} ${result.originalAmount} @ ${result.fxRate}`
+                : `${result.currency} ${result.originalAmount} (환율 없음)`;
+            original.title = result.fxRateDate ? `환율 고시일: ${result.fxRateDate}` : '';
+            cell.appendChild(original);
+        }
+        return cell;
+    },
+    
+    // 공급가액 셀 (수정 시 사용액 = 공급가액 + 부가세)
+    _createSupplyAmountCell(result, index) {
+        return this._createVATPartCell(result.supplyAmount, index, 'supplyAmount');
+    },
+    
+    // 부가세 셀 (면세는 0)
+    _createVATAmountCell(result, index) {
+        const cell = this._createVATPartCell(result.vatAmount, index, 'vatAmount');
+        if (result.taxExempt) {
+            cell.title = '면세';
+        }
+        return cell;
+    },
+    
+    _createVATPartCell(value, index, field) {
+        const cell = document.createElement('td');
+        const input = document.createElement('input');
+        input.type = 'text';
+        input.value = value || '';
+        
+        input.addEventListener('input', (e) => {
+            const result = ocrResults[index];
+            result[field] = e.target.value;
+            
+            const total = AmountUtils.parse(result.supplyAmount) + AmountUtils.parse(result.vatAmount);
+            result.amount = String(total);
+            const amountInput = document.querySelector(`#resultsTable tbody tr:nth-child(${index + 1}) td:nth-child(6) input`);
+            if (amountInput) {
+                amountInput.value = result.amount;
+            }
         });
         
         cell.appendChild(input);
         return cell;
     },
     
+    // 사용액 변경 시 공급가액/부가세 재계산 (10/110, 면세는 부가세 0)
+    _updateVATSplit(index) {
+        const result = ocrResults[index];
+        const total = AmountUtils.parse(result.amount);
+        const supply = result.taxExempt ? total : Math.round(total * 10 / 11);
+        
+        result.supplyAmount = String(supply);
+        result.vatAmount = String(total - supply);
+        
+        const row = document.querySelector(`#resultsTable tbody tr:nth-child(${index + 1})`);
+        if (row) {
+            row.querySelector('td:nth-child(7) input').value = result.supplyAmount;
+            row.querySelector('td:nth-child(8) input').value = result.vatAmount;
+        }
+    },
+    
     // 사용일 셀
     _createIssueDateCell(result, index) {
         const cell = document.createElement('td');
@@ -157,9 +292,14 @@ const ResultsManager = {
         input.type = 'text';
         input.value = result.issueDate || '';
         input.placeholder = 'YYYYMMDD';
+        const parse = result.issueDateParse;
+        if (parse && (parse.guessed || (parse.inferred && parse.inferred.length > 0))) {
+            input.classList.add('date-guessed');
+        }
         
         input.addEventListener('input', (e) => {
             ocrResults[index].issueDate = e.target.value;
+            input.classList.remove('date-guessed');
             this._updateResultRemark(index);
         });
         
@@ -195,7 +335,7 @@ const ResultsManager = {
         }
         
         // 국내출장인 경우 특별 처리 (출장내용과 용도 필요)
-        if (result.category === '6320') {
+        if (CategoryUtils.requiresBusinessInfo(result.category)) {
             console.log(`국내출장 파일 ${index + 1}: 비고는 출장내용과 용도가 필요하므로 자동 업데이트하지 않음`);
             return;
         }
@@ -203,7 +343,7 @@ const ResultsManager = {
         // 추가 이름 찾기
         let finalUserName = userName;
         const matchingFile = selectedFiles.find(fileData => 
-            fileData.file.name === result.fileName
+            fileData.file.name === (result.sourceFile || result.fileName)
         );
         
         if (matchingFile && matchingFile.additionalNames) {
@@ -223,16 +363,30 @@ const ResultsManager = {
         console.log(`OCR 결과 ${index + 1} 비고 업데이트: ${newRemark}`);
     },
     
-    // Excel 다운로드 처리
-    async downloadExcel() {
+    // Excel 다운로드 처리 (정책 위반으로 거부되면 사유를 입력받아 재요청)
+    async downloadExcel(overrideReason = '') {
         const downloadBtn = document.getElementById('downloadBtn');
         const originalText = downloadBtn.textContent;
         
+        // 중복 의심 항목이 있으면 포함 여부 확인 (사유 입력 후 재요청 시에는 다시 묻지 않음)
+        const duplicateCount = ocrResults.filter(result => result.duplicate).length;
+        let allowDuplicates = duplicateCount > 0 && overrideReason !== '';
+        if (duplicateCount > 0 && !allowDuplicates) {
+            allowDuplicates = confirm(`중복 의심 영수증 ${duplicateCount}건이 있습니다.\n그대로 Excel에 포함하시겠습니까?`);
+            if (!allowDuplicates) return;
+        }
+        
         try {
             UIUtils.setButtonState('downloadBtn', true, '다운로드 중...');
             
             // 다운로드용 FormData 생성
             const formData = this._createDownloadFormData();
+            if (allowDuplicates) {
+                formData.append('allow_duplicates', 'true');
+            }
+            if (overrideReason) {
+                formData.append('override_reason', overrideReason);
+            }
             
             // 다운로드 요청
             const response = await fetch(CONFIG.API.DOWNLOAD_EXCEL, {
@@ -245,6 +399,20 @@ const ResultsManager = {
                 UIUtils.showSuccess('✅ Excel 파일이 성공적으로 다운로드되었습니다!');
             } else {
                 const errorData = await response.json();
+                if (errorData.violations) {
+                    const details = errorData.violations
+                        .map(result => `- ${result.fileName}: ${result.violations.map(v => v.message).join(', ')}`)
+                        .join('\n');
+                    const reason = prompt(`${errorData.error}\n\n${details}\n\n내보내기 사유:`);
+                    if (reason && reason.trim()) {
+                        UIUtils.setButtonState('downloadBtn', false, originalText);
+                        return this.downloadExcel(reason.trim());
+                    }
+                }
+                if (errorData.duplicates) {
+                    const fileNames = errorData.duplicates.map(result => result.fileName).join(', ');
+                    throw new Error(`${errorData.error} (${fileNames})`);
+                }
                 throw new Error(errorData.error || '다운로드 중 오류가 발생했습니다.');
             }
             
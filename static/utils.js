@@ -77,8 +77,66 @@ const DateUtils = {
     }
 };
 
+// 금액 관련 유틸리티
+const AmountUtils = {
+    // 금액 문자열 파싱 (예: "12,000원" -> 12000, "-5,000" -> -5000, "12,300.50" -> 12300.5)
+    parse(amountText) {
+        const text = String(amountText || '')
+            .replace(/[０-９]/g, ch => String.fromCharCode(ch.charCodeAt(0) - 0xFEE0))
+            .replace(/，/g, ',').replace(/．/g, '.').replace(/[－−]/g, '-');
+        const match = text.match(/(-?)[^0-9-]*([0-9][0-9,]*(?:\.[0-9]+)?)/);
+        if (!match) {
+            return 0;
+        }
+        const negative = match[1] === '-' || /\(\s*[₩￦]?\s*[0-9][0-9,.]*\s*\)/.test(text);
+        const amount = parseFloat(match[2].replace(/,/g, '')) || 0;
+        return negative ? -amount : amount;
+    }
+};
+
 // 카테고리 관련 유틸리티
 const CategoryUtils = {
+    // 서버 카테고리 목록 로드 (실패 시 config.js 기본값 유지)
+    async load() {
+        try {
+            const response = await fetch(CONFIG.API.CATEGORIES);
+            if (!response.ok) {
+                throw new Error(`HTTP ${response.status}`);
+            }
+            const catalog = await response.json();
+            const active = catalog.categories.filter(category => category.active);
+            
+            CATEGORY_OPTIONS = active.map(category => ({ label: category.label, value: category.code }));
+            CATEGORY_DEFINITIONS = {};
+            CATEGORY_KEYWORDS = {};
+            catalog.categories.forEach(category => {
+                CATEGORY_DEFINITIONS[category.code] = category;
+                if (category.active && category.keywords && category.keywords.length > 0) {
+                    CATEGORY_KEYWORDS[category.code] = category.keywords;
+                }
+            });
+            DEFAULT_CATEGORY = catalog.default;
+            console.log(`카테고리 ${CATEGORY_OPTIONS.length}개 로드됨 (기본값: ${DEFAULT_CATEGORY})`);
+        } catch (error) {
+            console.warn('카테고리 목록 로드 실패, 기본값 사용:', error);
+        }
+    },
+    
+    getDefault() {
+        return DEFAULT_CATEGORY;
+    },
+    
+    getRequiredFields(categoryValue) {
+        const definition = CATEGORY_DEFINITIONS[categoryValue];
+        return (definition && definition.requiredFields) || [];
+    },
+    
+    // 출장내용/용도 입력이 필요한 카테고리 (국내출장 등)
+    requiresBusinessInfo(categoryValue) {
+        const requiredFields = this.getRequiredFields(categoryValue);
+        return requiredFields.includes('business_content') || requiredFields.includes('purpose');
+    },
+    
     getLabel(categoryValue) {
         const option = CATEGORY_OPTIONS.find(opt => opt.value === categoryValue);
         return option ? option.label : categoryValue;
@@ -95,8 +153,8 @@ const CategoryUtils = {
             }
         }
         
-        console.log(`파일 "${filename}": 특별한 키워드 없음, 기본값 석식으로 설정`);
-        return '6130';
+        console.log(`파일 "${filename}": 특별한 키워드 없음, 기본값 ${this.getLabel(this.getDefault())}으로 설정`);
+        return this.getDefault();
     }
 };
 
@@ -143,8 +201,13 @@ const RemarkUtils = {
         
         const monthDay = DateUtils.formatToMMDD(issueDate);
         const categoryLabel = CategoryUtils.getLabel(categoryValue);
+        const definition = CATEGORY_DEFINITIONS[categoryValue];
+        const template = (definition && definition.remarkTemplate) || '{date}_{names}_{label}';
         
-        return `${monthDay}_${userName}_${categoryLabel}`;
+        return template
+            .replace('{date}', monthDay)
+            .replace('{names}', userName)
+            .replace('{label}', categoryLabel);
     },
     
     generateBusinessTrip(businessContent, userName, additionalNames, purpose) {
@@ -257,7 +320,7 @@ const ValidationUtils = {
     },
     
     validateBusinessTripFields(fileData) {
-        if (fileData.category === '6320') {
+        if (CategoryUtils.requiresBusinessInfo(fileData.category)) {
             if (!fileData.businessContent || !fileData.businessContent.trim()) {
                 return {
                     isValid: false,
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// 공급가/부가세 산출 근거
+const (
+	VAT_SOURCE_OCR     = "ocr"     // OCR 공급가/부가세 필드 사용
+	VAT_SOURCE_DERIVED = "derived" // 합계 금액에서 10/110으로 계산
+	VAT_SOURCE_EXEMPT  = "exempt"  // 면세 (부가세 0)
+)
+
+// VATSplit 공급가액/부가세 분리 결과
+type VATSplit struct {
+	Total     Money
+	Supply    Money
+	VAT       Money
+	TaxExempt bool
+	Source    string
+}
+
+// 합계와 OCR 공급가/부가세(없으면 0)로 공급가액/부가세 분리
+func splitVAT(total, supply, vat Money, merchant string) VATSplit {
+	if total.IsZero() {
+		total = supply + vat
+	}
+
+	// 면세 사용처는 전액 공급가액
+	if isTaxExemptMerchant(merchant) {
+		return VATSplit{Total: total, Supply: total, VAT: 0, TaxExempt: true, Source: VAT_SOURCE_EXEMPT}
+	}
+
+	switch {
+	case !supply.IsZero() && !vat.IsZero():
+		if supply+vat != total {
+			log.Printf("⚠️ 공급가(%s) + 부가세(%s)가 사용액(%s)과 다릅니다. OCR 필드 값을 사용합니다", supply, vat, total)
+		}
+		return VATSplit{Total: supply + vat, Supply: supply, VAT: vat, Source: VAT_SOURCE_OCR}
+	case total > 0 && supply > 0 && supply < total:
+		return VATSplit{Total: total, Supply: supply, VAT: total - supply, Source: VAT_SOURCE_OCR}
+	case total > 0 && vat > 0 && vat < total:
+		return VATSplit{Total: total, Supply: total - vat, VAT: vat, Source: VAT_SOURCE_OCR}
+	}
+
+	// 합계만 있으면 10/110 비율로 계산 (공급가액 원 단위 반올림, 부가세는 차액)
+	supply = total.Ratio(10, 11)
+	return VATSplit{Total: total, Supply: supply, VAT: total - supply, Source: VAT_SOURCE_DERIVED}
+}
+
+// OCR 필드에서 공급가액/부가세 분리
+func (s *OCRService) SplitVAT(fields []Field) VATSplit {
+	merchant := s.ExtractFieldValue(fields, "사용처")
+	total := s.CalculateAmount(fields)
+	supply := s.parseAmount(s.ExtractFieldValue(fields, "공급가"))
+	vatText := s.ExtractFieldValue(fields, "부가세")
+	vat := s.parseAmount(vatText)
+
+	var split VATSplit
+	if vatText != "" && vat.IsZero() && !supply.IsZero() && (total.IsZero() || supply == total) {
+		// 영수증에 부가세 0원이 명시된 경우 면세로 처리
+		split = VATSplit{Total: supply, Supply: supply, VAT: 0, TaxExempt: true, Source: VAT_SOURCE_EXEMPT}
+	} else {
+		split = splitVAT(total, supply, vat, merchant)
+	}
+	log.Printf("공급가액/부가세 분리: 합계 %s = 공급가액 %s + 부가세 %s (근거: %s)", split.Total, split.Supply, split.VAT, split.Source)
+	return split
+}
+
+// 결과의 공급가액/부가세 (비어 있거나 사용액과 맞지 않으면 사용액에서 다시 계산)
+func resultVATSplit(result OCRResult) VATSplit {
+	supply, vat, total := result.SupplyAmount, result.VATAmount, result.Amount
+
+	if result.TaxExempt {
+		if total.IsZero() {
+			total = supply
+		}
+		return VATSplit{Total: total, Supply: total, VAT: 0, TaxExempt: true, Source: VAT_SOURCE_EXEMPT}
+	}
+	if !supply.IsZero() || !vat.IsZero() {
+		if total.IsZero() || supply+vat == total {
+			return VATSplit{Total: supply + vat, Supply: supply, VAT: vat, Source: VAT_SOURCE_OCR}
+		}
+		// 결과 표에서 사용액을 고친 경우 기존 공급가액/부가세 대신 사용액 기준으로 다시 분리
+		log.Printf("⚠️ 공급가(%s) + 부가세(%s)가 사용액(%s)과 달라 사용액 기준으로 다시 분리합니다 - 파일: %s", supply, vat, total, result.FileName)
+	}
+	return splitVAT(total, 0, 0, result.Purpose)
+}
+
+// 면세 사용처 여부 (TAX_EXEMPT_KEYWORDS)
+func isTaxExemptMerchant(merchant string) bool {
+	merchant = strings.ToLower(merchant)
+	if merchant == "" {
+		return false
+	}
+	for _, keyword := range getTaxExemptKeywords() {
+		if strings.Contains(merchant, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}